@@ -0,0 +1,34 @@
+// Command upwork-deprecations reports calls to the deprecated methods of Upwork API routers
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+
+// Usage:
+//
+//	upwork-deprecations ./...
+//
+// or as a vet tool
+//
+//	cd analysis && go build -o upwork-deprecations ./cmd/upwork-deprecations
+//	go vet -vettool=/path/to/upwork-deprecations ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/upwork/golang-upwork-oauth2/analysis/deprecations"
+)
+
+func main() {
+	singlechecker.Main(deprecations.Analyzer)
+}
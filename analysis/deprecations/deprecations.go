@@ -0,0 +1,95 @@
+// Analyzer for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+
+// Package deprecations reports calls to the legacy router methods, which compile
+// fine but return api.ErrDeprecated at runtime, and suggests the GraphQL replacement.
+package deprecations
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+)
+
+// Import path of the routers
+const RoutersPath = "github.com/upwork/golang-upwork-oauth2/api/routers/"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "upworkdeprecations",
+	Doc:      "report calls to the deprecated methods of Upwork API routers and suggest their GraphQL replacements",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+
+		method := routerMethod(pass.TypesInfo.Uses[sel.Sel])
+		if method == "" {
+			return
+		}
+
+		d, ok := api.LookupDeprecation(method)
+		if !ok {
+			return
+		}
+		if len(d.Replacements) == 0 {
+			pass.Reportf(sel.Sel.Pos(), "%s is deprecated and returns api.ErrDeprecated, there is no replacement available", method)
+			return
+		}
+		pass.Reportf(sel.Sel.Pos(), "%s is deprecated and returns api.ErrDeprecated, use GraphQL %s instead", method, strings.Join(d.Replacements, " or "))
+	})
+
+	return nil, nil
+}
+
+// Get the name of a router method as used in the deprecations registry, e.g. "hr/milestones.Create"
+func routerMethod(obj types.Object) string {
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return ""
+	}
+	// the legacy routers are unexported, the typed services share the method names
+	recv := sig.Recv().Type()
+	if p, ok := recv.(*types.Pointer); ok {
+		recv = p.Elem()
+	}
+	if named, ok := recv.(*types.Named); !ok || named.Obj().Exported() {
+		return ""
+	}
+	if !strings.HasPrefix(fn.Pkg().Path(), RoutersPath) {
+		return ""
+	}
+
+	return strings.TrimPrefix(fn.Pkg().Path(), RoutersPath) + "." + fn.Name()
+}
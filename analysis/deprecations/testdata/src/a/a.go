@@ -0,0 +1,28 @@
+package a
+
+import (
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/messages"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/metadata"
+)
+
+type rooms interface {
+	GetRooms(company string, params map[string]string) (interface{}, error)
+}
+
+func f() {
+	router := messages.New()
+	router.GetRooms("company", nil)                 // want `messages.GetRooms is deprecated and returns api.ErrDeprecated, use GraphQL query roomList instead`
+	messages.New().SendMessageToRoom("c", "r", nil) // want `messages.SendMessageToRoom is deprecated .* use GraphQL mutation sendMessageToRoom instead`
+	(router.GetRooms)("company", nil)               // want `messages.GetRooms is deprecated`
+
+	metadata.New().GetTests()        // want `metadata.GetTests is deprecated and returns api.ErrDeprecated, there is no replacement available`
+	metadata.New().GetCategoriesV2() // want `metadata.GetCategoriesV2 is deprecated .* use GraphQL query ontologyCategories instead`
+
+	graphql.New().Execute(nil)
+	(&messages.Service{}).CreateRoom("room")
+
+	// calls through an interface are not resolved to the router
+	var r rooms = router
+	r.GetRooms("company", nil)
+}
@@ -0,0 +1,15 @@
+package messages
+
+type a struct{}
+
+func New() *a { return &a{} }
+
+func (r a) GetRooms(company string, params map[string]string) (interface{}, error) { return nil, nil }
+
+func (r a) SendMessageToRoom(company string, roomId string, params map[string]string) (interface{}, error) {
+	return nil, nil
+}
+
+type Service struct{}
+
+func (s *Service) CreateRoom(roomName string) (interface{}, error) { return nil, nil }
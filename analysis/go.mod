@@ -0,0 +1,22 @@
+module github.com/upwork/golang-upwork-oauth2/analysis
+
+go 1.22.0
+
+require (
+	github.com/upwork/golang-upwork-oauth2 v0.0.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.3.2 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/net v0.30.0 // indirect
+	golang.org/x/oauth2 v0.9.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	google.golang.org/appengine v1.6.7 // indirect
+	google.golang.org/protobuf v1.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/upwork/golang-upwork-oauth2 => ../
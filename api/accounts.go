@@ -0,0 +1,225 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Accounts not used for this time are evicted from AccountManager by default
+const DefaultIdleTimeout = 30 * time.Minute
+
+// TokenStoreFunc returns the token store of a specific account
+type TokenStoreFunc func(account string) TokenStore
+
+// Keep a token file per account in a specific directory
+func TokenDir(dir string) TokenStoreFunc {
+	return func(account string) TokenStore {
+		return NewTokenFile(filepath.Join(dir, url.PathEscape(account)+".json"))
+	}
+}
+
+// AccountManager serves many authorized Upwork users of one application. It holds
+// a single oauth2 config and http transport, and hands out a lightweight client per
+// account with the token pair loaded from, and refreshed into, the account's store.
+type AccountManager struct {
+	config     *Config
+	oconf      *oauth2.Config
+	stores     TokenStoreFunc
+	transport  *http.Transport
+	reauthfunc ReauthFunc
+	idle       time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	accounts map[string]*managedAccount
+}
+
+type managedAccount struct {
+	client   *ApiClient
+	lastUsed time.Time
+}
+
+// Create a manager for the application configured in config, tokens of the
+// accounts are kept in the stores. The client_credentials grant authorizes the
+// application itself rather than its users, so it is not supported, use Setup instead.
+func NewAccountManager(config *Config, stores TokenStoreFunc) (*AccountManager, error) {
+	if config.GrantType == "client_credentials" {
+		return nil, fmt.Errorf("upwork: the client_credentials grant is not supported by AccountManager")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns // all accounts talk to the same hosts
+
+	return &AccountManager{
+		config: config,
+		oconf: &oauth2.Config{
+			ClientID:     config.ClientId,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectUri,
+			Endpoint: oauth2.Endpoint{
+				TokenURL: AccessTokenEP,
+				AuthURL:  AuthorizationEP,
+			},
+			Scopes: config.Scopes,
+		},
+		stores:    stores,
+		transport: transport,
+		idle:      DefaultIdleTimeout,
+		now:       time.Now,
+		accounts:  make(map[string]*managedAccount),
+	}, nil
+}
+
+// Set the time after which an unused account is evicted
+func (m *AccountManager) SetIdleTimeout(d time.Duration) {
+	m.mu.Lock()
+	m.idle = d
+	m.mu.Unlock()
+}
+
+// Set a hook called when the refresh token of an account is revoked or expired
+func (m *AccountManager) OnReauthRequired(f ReauthFunc) {
+	m.mu.Lock()
+	m.reauthfunc = f
+	m.mu.Unlock()
+}
+
+// Receive an authorization URL for a new account
+func (m *AccountManager) GetAuthorizationUrl(stateString string, opts ...oauth2.AuthCodeOption) string {
+	return m.oconf.AuthCodeURL(stateString, opts...)
+}
+
+// Authorize an account using a specific authorization code, the token pair is
+// saved in the account's store
+func (m *AccountManager) Authorize(ctx context.Context, account string, authzCode string) (*ApiClient, error) {
+	ctx = m.accountConfig(account).SetOwnHttpClient(ctx)
+	t, err := m.oconf.Exchange(ctx, strings.Trim(authzCode, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	if err := m.stores(account).SaveToken(t); err != nil {
+		return nil, err
+	}
+
+	m.Evict(account)
+	return m.Client(account)
+}
+
+// Get the client of a specific account. An account without a saved token
+// requires authorization, ErrReauthorizationRequired is returned in that case.
+func (m *AccountManager) Client(account string) (*ApiClient, error) {
+	m.mu.Lock()
+	now := m.now()
+	m.evictIdle(now)
+	if a, ok := m.accounts[account]; ok {
+		a.lastUsed = now
+		m.mu.Unlock()
+		return a.client, nil
+	}
+	reauthfunc := m.reauthfunc
+	m.mu.Unlock()
+
+	// the store and the hook may be slow or call back into the manager, run them unlocked
+	store := m.stores(account)
+	t, err := store.LoadToken()
+	if err == ErrTokenNotFound {
+		rerr := &ReauthorizationError{Account: account, Err: err}
+		if reauthfunc != nil {
+			rerr.AuthUrl = reauthfunc(account)
+		}
+		return nil, rerr
+	} else if err != nil {
+		return nil, err
+	}
+
+	c := &ApiClient{
+		oconf:      m.oconf,
+		token:      t,
+		config:     m.accountConfig(account),
+		store:      store,
+		reauthfunc: reauthfunc,
+	}
+	c.SetApiResponseType(ByteResponse)
+	c.SetPostAsJson(false)
+	c.setupOauth2Client(context.Background())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the account may have been loaded concurrently, keep the first client
+	if a, ok := m.accounts[account]; ok {
+		a.lastUsed = now
+		return a.client, nil
+	}
+	m.accounts[account] = &managedAccount{client: c, lastUsed: now}
+
+	return c, nil
+}
+
+// Get a per-account copy of the config, without the application's own token pair;
+// the account's tokens are only saved in its store, never in the application's config file
+func (m *AccountManager) accountConfig(account string) *Config {
+	cfg := *m.config
+	cfg.Account = account
+	cfg.AccessToken, cfg.RefreshToken, cfg.ExpiresAt = "", "", time.Time{}
+	cfg.HasCustomHttpClient = false
+	cfg.AutoPersist, cfg.file, cfg.profile, cfg.sources = false, "", "", nil
+	cfg.transport = m.transport
+	return &cfg
+}
+
+// Remove a specific account from the manager, its token stays in the store
+func (m *AccountManager) Evict(account string) {
+	m.mu.Lock()
+	delete(m.accounts, account)
+	m.mu.Unlock()
+}
+
+// Remove the accounts not used for the idle timeout, returns the number of evicted accounts
+func (m *AccountManager) EvictIdle() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.evictIdle(m.now())
+}
+
+// Number of the accounts currently held by the manager
+func (m *AccountManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.accounts)
+}
+
+func (m *AccountManager) evictIdle(now time.Time) int {
+	n := 0
+	for account, a := range m.accounts {
+		if now.Sub(a.lastUsed) > m.idle {
+			delete(m.accounts, account)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestAccountManager(t *testing.T) {
+	srv := &tokenServer{refreshToken: "refresh-0"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	stores := TokenDir(t.TempDir())
+	assert.NoError(t, stores("john").SaveToken(&oauth2.Token{AccessToken: "john-token", RefreshToken: "john-refresh", Expiry: time.Now().Add(time.Hour)}))
+	assert.NoError(t, stores("jane/doe").SaveToken(&oauth2.Token{AccessToken: "jane-token", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}))
+
+	m, err := NewAccountManager(&Config{ClientId: "clientid", ClientSecret: "clientsecret", RedirectUri: "https://a.callback.url"}, stores)
+	if !assert.NoError(t, err) {
+		return
+	}
+	m.oconf.Endpoint.TokenURL = ts.URL
+
+	ping := func(c *ApiClient) string {
+		resp, err := c.oclient.Get(ts.URL + "/ping")
+		if assert.NoError(t, err) {
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			return string(b)
+		}
+		return ""
+	}
+
+	john, err := m.Client("john")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Bearer john-token", ping(john))
+		assert.Equal(t, "john", john.config.Account)
+	}
+
+	// expired token is refreshed and saved in the account's store
+	jane, err := m.Client("jane/doe")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Bearer access-1", ping(jane))
+		token, _ := stores("jane/doe").LoadToken()
+		assert.Equal(t, "refresh-1", token.RefreshToken)
+	}
+
+	// clients are reused and share one transport
+	again, _ := m.Client("john")
+	assert.True(t, john == again)
+	assert.True(t, john.config.transport == jane.config.transport)
+	assert.Equal(t, 2, m.Len())
+
+	_, err = m.Client("unknown")
+	assert.True(t, errors.Is(err, ErrReauthorizationRequired))
+}
+
+func TestAccountManagerKeepsConfigFile(t *testing.T) {
+	ts := httptest.NewServer(&tokenServer{refreshToken: "refresh-0"})
+	defer ts.Close()
+
+	fn := filepath.Join(t.TempDir(), "config.json")
+	content := `{"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url",
+		"access_token": "app-access", "refresh_token": "app-refresh", "auto_persist": true}`
+	assert.NoError(t, os.WriteFile(fn, []byte(content), 0600))
+
+	stores := TokenDir(t.TempDir())
+	assert.NoError(t, stores("jane").SaveToken(&oauth2.Token{AccessToken: "jane-token", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}))
+
+	m, err := NewAccountManager(ReadConfig(fn), stores)
+	if !assert.NoError(t, err) {
+		return
+	}
+	m.oconf.Endpoint.TokenURL = ts.URL
+
+	jane, err := m.Client("jane")
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := jane.oclient.Get(ts.URL + "/ping")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	token, _ := stores("jane").LoadToken()
+	assert.Equal(t, "refresh-1", token.RefreshToken)
+	b, _ := os.ReadFile(fn)
+	assert.Equal(t, content, string(b))
+}
+
+func TestAccountManagerEviction(t *testing.T) {
+	stores := TokenDir(t.TempDir())
+	for _, account := range []string{"john", "jane"} {
+		assert.NoError(t, stores(account).SaveToken(&oauth2.Token{AccessToken: account, RefreshToken: account}))
+	}
+
+	now := time.Now()
+	m, _ := NewAccountManager(&Config{ClientId: "clientid", ClientSecret: "clientsecret"}, stores)
+	m.SetIdleTimeout(time.Minute)
+	m.now = func() time.Time { return now }
+
+	john, _ := m.Client("john")
+	now = now.Add(45 * time.Second)
+	m.Client("jane")
+	now = now.Add(30 * time.Second)
+
+	assert.Equal(t, 1, m.EvictIdle())
+	assert.Equal(t, 1, m.Len())
+
+	// an evicted account gets a new client on the next use
+	again, err := m.Client("john")
+	if assert.NoError(t, err) {
+		assert.False(t, john == again)
+		assert.Equal(t, "john", again.token.AccessToken)
+	}
+}
+
+func TestAccountManagerClientCredentials(t *testing.T) {
+	_, err := NewAccountManager(&Config{ClientId: "clientid", ClientSecret: "clientsecret", GrantType: "client_credentials"}, TokenDir(t.TempDir()))
+	assert.Error(t, err)
+}
+
+func TestAccountManagerAuthorize(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "access", "refresh_token": "refresh", "token_type": "bearer", "expires_in": 3600}`)
+	}))
+	defer ts.Close()
+
+	stores := TokenDir(t.TempDir())
+	m, _ := NewAccountManager(&Config{ClientId: "clientid", ClientSecret: "clientsecret"}, stores)
+	m.oconf.Endpoint.TokenURL = ts.URL
+
+	c, err := m.Authorize(context.Background(), "john", "code")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "access", c.token.AccessToken)
+	}
+	assert.Equal(t, UPWORK_LIBRARY_USER_AGENT, userAgent)
+	token, _ := stores("john").LoadToken()
+	assert.Equal(t, "refresh", token.RefreshToken)
+}
+
+func TestAccountManagerReauthHook(t *testing.T) {
+	m, _ := NewAccountManager(&Config{ClientId: "clientid", ClientSecret: "clientsecret"}, TokenDir(t.TempDir()))
+
+	// the hook may use the manager, it is called without holding the lock
+	m.OnReauthRequired(func(account string) string {
+		return fmt.Sprintf("https://a.callback.url/%s/%d", account, m.Len())
+	})
+
+	_, err := m.Client("john")
+	var rerr *ReauthorizationError
+	if assert.True(t, errors.As(err, &rerr)) {
+		assert.Equal(t, "https://a.callback.url/john/0", rerr.AuthUrl)
+	}
+}
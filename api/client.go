@@ -18,12 +18,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
@@ -37,6 +39,7 @@ const (
 	GqlEndpoint               = "https://api.upwork.com/graphql"
 	AuthorizationEP           = BaseHost + "ab/account-security/oauth2/authorize"
 	AccessTokenEP             = BaseHost + DefaultEpoint + "/v3/oauth2/token"
+	RevokeTokenEP             = BaseHost + DefaultEpoint + "/v3/oauth2/token/revoke"
 	DataFormat                = "json"
 	OverloadParam             = "http_method"
 	UPWORK_LIBRARY_USER_AGENT = "Github Upwork API Golang Library"
@@ -58,6 +61,15 @@ type ApiClient struct {
 	// refresh token notify function
 	rnfunc TokenNotifyFunc
 
+	// optional storage of the token pair, shared between processes
+	store TokenStore
+
+	// re-authorization hook
+	reauthfunc ReauthFunc
+
+	// last token obtained by the authorized client
+	last *lastToken
+
 	// client
 	ep                  string
 	respType            string
@@ -82,6 +94,11 @@ type NotifyingTokenSource struct {
 func Setup(config *Config) (client ApiClient) {
 	var c ApiClient
 
+	if config.AutoPersist {
+		if err := config.checkAutoPersist(); err != nil {
+			log.Fatal(err)
+		}
+	}
 	c.config = config
 
 	if config.GrantType == "client_credentials" {
@@ -89,6 +106,7 @@ func Setup(config *Config) (client ApiClient) {
 			ClientID:     config.ClientId,
 			ClientSecret: config.ClientSecret,
 			TokenURL:     AccessTokenEP,
+			Scopes:       config.Scopes,
 		}
 	} else {
 		c.oconf = &oauth2.Config{
@@ -99,6 +117,7 @@ func Setup(config *Config) (client ApiClient) {
 				TokenURL: AccessTokenEP,
 				AuthURL:  AuthorizationEP,
 			},
+			Scopes: config.Scopes,
 		}
 	}
 
@@ -142,6 +161,18 @@ func (c *ApiClient) SetRefreshTokenNotifyFunc(f TokenNotifyFunc) {
 	c.rnfunc = f
 }
 
+// Set a hook called when the refresh token is revoked or expired. Requests then
+// fail with ErrReauthorizationRequired instead of terminating the program.
+func (c *ApiClient) OnReauthRequired(f ReauthFunc) {
+	c.reauthfunc = f
+}
+
+// Set a store used to load, save and refresh the token pair, e.g. a TokenFile
+// shared by several processes using the same credentials
+func (c *ApiClient) SetTokenStore(s TokenStore) {
+	c.store = s
+}
+
 // Set request type for non-Get requests
 func (c *ApiClient) SetPostAsJson(t bool) {
 	c.sendPostAsJson = t
@@ -157,9 +188,10 @@ func (c *ApiClient) SetEntryPoint(ep string) {
 	c.ep = ep
 }
 
-// Receive an authorization URL in Code Authorization Grant
-func (c *ApiClient) GetAuthorizationUrl(stateString string) (authzUrl string) {
-	url := c.oconf.AuthCodeURL(stateString)
+// Receive an authorization URL in Code Authorization Grant, extra parameters
+// can be passed using options, e.g. oauth2.SetAuthURLParam
+func (c *ApiClient) GetAuthorizationUrl(stateString string, opts ...oauth2.AuthCodeOption) (authzUrl string) {
+	url := c.oconf.AuthCodeURL(stateString, opts...)
 	if url == "" {
 		log.Fatal("Can not get authorization URL using OAuth2 library")
 	}
@@ -187,6 +219,14 @@ func (c *ApiClient) GetToken(ctx context.Context, authzCode string) *oauth2.Toke
 	}
 
 	c.token = accessToken
+	if c.store != nil {
+		if err := c.store.SaveToken(accessToken); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := c.config.saveToken(accessToken); err != nil {
+		log.Fatal(err)
+	}
 	c.setupOauth2Client(ctx)
 
 	return accessToken
@@ -194,6 +234,13 @@ func (c *ApiClient) GetToken(ctx context.Context, authzCode string) *oauth2.Toke
 
 // Check if client contains already a access/refresh token pair
 func (c *ApiClient) HasAccessToken(ctx context.Context) bool {
+	// a stored token is newer than the one from config
+	if c.store != nil {
+		if t, err := c.store.LoadToken(); err == nil {
+			c.token = t
+		}
+	}
+
 	has := (c.token != nil && (c.token.AccessToken != "" && c.token.RefreshToken != ""))
 	if has {
 		c.setupOauth2Client(ctx)
@@ -201,6 +248,33 @@ func (c *ApiClient) HasAccessToken(ctx context.Context) bool {
 	return has
 }
 
+// Get the scopes granted to the client as reported by the token endpoint for the
+// last obtained token, the list is empty if the token endpoint has not reported them.
+// The token is never refreshed by this call.
+func (c *ApiClient) GrantedScopes() []string {
+	var scopes []string
+	if t, ok := c.currentToken(); ok {
+		scopes = tokenScopes(t)
+	}
+	if scopes == nil && c.token != nil {
+		// the scope is omitted in a refresh response if it has not changed
+		scopes = tokenScopes(c.token)
+	}
+
+	return scopes
+}
+
+// Check if a specific scope is granted to the client, e.g. before calling
+// a GraphQL field that requires it
+func (c *ApiClient) HasScope(scope string) bool {
+	for _, s := range c.GrantedScopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // GET method for client
 func (c *ApiClient) Get(uri string, params map[string]string) (r *http.Response, re interface{}) {
 	// parameters must be encoded according to RFC 3986
@@ -243,26 +317,78 @@ func (c *ApiClient) Delete(uri string, params map[string]string) (r *http.Respon
 	return c.sendPostRequest(uri, addOverloadParam(params, "delete"))
 }
 
+// Send a prepared request using the authorized client, e.g. a typed GraphQL request
+func (c *ApiClient) Do(req *http.Request) (*http.Response, error) {
+	if c.oclient == nil {
+		return nil, fmt.Errorf("upwork: the client is not authorized yet, use HasAccessToken or GetToken first")
+	}
+	return c.oclient.Do(req)
+}
+
+// Get the URL of the GraphQL endpoint
+func (c *ApiClient) GraphqlUrl() string {
+	if c.config.GraphqlUrl != "" {
+		return c.config.GraphqlUrl
+	}
+	return GqlEndpoint
+}
+
 // setup/save authorized oauth2 client, based on received or provided access/refresh token pair
 func (c *ApiClient) setupOauth2Client(ctx context.Context) {
 	if c.hasCustomHttpClient == false {
 		ctx = c.config.SetOwnHttpClient(ctx)
 	}
 
-	if c.rnfunc != nil && c.config.GrantType != "client_credentials" {
-		// setup notifier for token-refresh workflow - https://github.com/golang/oauth2/issues/84
-		realSource := c.oconf.TokenSource(ctx, c.token)
-		notifyingSrc := NewNotifyingTokenSource(realSource, c.rnfunc)
-		notifyingWithInitialSrc := oauth2.ReuseTokenSource(c.token, notifyingSrc)
-		// setup authorized oauth2 client
-		c.oclient = oauth2.NewClient(ctx, notifyingWithInitialSrc)
-	} else {
-		if c.config.GrantType == "client_credentials" {
-			c.oclient = c.cconf.Client(ctx)
-		} else {
-			c.oclient = c.oconf.Client(ctx, c.token)
+	c.last = &lastToken{}
+	if c.config.GrantType == "client_credentials" {
+		src := NewNotifyingTokenSource(c.cconf.TokenSource(ctx), c.last.set)
+		c.oclient = oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, src))
+		return
+	}
+
+	// setup notifier for token-refresh workflow - https://github.com/golang/oauth2/issues/84
+	realSource := c.oconf.TokenSource(ctx, c.token)
+	if c.store != nil {
+		realSource = &storeTokenSource{ctx: ctx, conf: c.oconf, store: c.store, t: c.token}
+	}
+	notifyingSrc := NewNotifyingTokenSource(realSource, c.rnfunc)
+	persistingSrc := NewNotifyingTokenSource(notifyingSrc, c.config.saveToken)
+	recordingSrc := NewNotifyingTokenSource(persistingSrc, c.last.set)
+	reauthSrc := &reauthTokenSource{src: recordingSrc, store: c.store, account: c.config.Account, f: c.reauthfunc}
+	notifyingWithInitialSrc := oauth2.ReuseTokenSource(c.token, reauthSrc)
+	// setup authorized oauth2 client
+	c.oclient = oauth2.NewClient(ctx, notifyingWithInitialSrc)
+}
+
+// get the token currently used by the authorized client, never refreshes it
+func (c *ApiClient) currentToken() (*oauth2.Token, bool) {
+	if c.last != nil {
+		if t := c.last.get(); t != nil {
+			return t, true
 		}
 	}
+	return c.token, c.token != nil
+}
+
+// lastToken keeps the last token obtained by a token source, see setupOauth2Client
+type lastToken struct {
+	mu sync.Mutex
+	t  *oauth2.Token
+}
+
+// record a new token, a TokenNotifyFunc
+func (l *lastToken) set(t *oauth2.Token) error {
+	l.mu.Lock()
+	l.t = t
+	l.mu.Unlock()
+	return nil
+}
+
+// get the recorded token, nil if there is none yet
+func (l *lastToken) get() *oauth2.Token {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.t
 }
 
 // setup X-Upwork-API-TenantId header
@@ -280,7 +406,7 @@ func (c *ApiClient) sendPostRequest(uri string, params map[string]string) (*http
 
 	if c.ep == "graphql" {
 		jsonStr, _ := json.Marshal(params) // params contain json data in this case
-		response, err = c.oclient.Post(GqlEndpoint, "application/json", bytes.NewBuffer(jsonStr))
+		response, err = c.oclient.Post(c.GraphqlUrl(), "application/json", bytes.NewBuffer(jsonStr))
 	} else if c.sendPostAsJson == true {
 		// old style for backward compatibility with the old library
 		var jsonStr = []byte("{}")
@@ -310,6 +436,11 @@ func (c *ApiClient) sendPostRequest(uri string, params map[string]string) (*http
 
 // return proper response type
 func (c *ApiClient) getTypedResponse(resp *http.Response, re error) (*http.Response, interface{}) {
+	// nothing to retry without a new authorization, let the caller handle it
+	if errors.Is(re, ErrReauthorizationRequired) {
+		return nil, re
+	}
+
 	if c.respType == ByteResponse {
 		r, b := formatResponse(resp, re)
 		return r, b.([]byte)
@@ -1,8 +1,14 @@
 package api
 
 import (
+    "context"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
     "testing"
+    "time"
     "github.com/stretchr/testify/assert"
+    "golang.org/x/oauth2"
 )
 
 func TestClientConstants(t *testing.T) {
@@ -28,3 +34,57 @@ func TestSetEntryPoint(t *testing.T) {
 
     assert.Equal(t, "gds", client.ep)
 }
+
+func TestGetAuthorizationUrl(t *testing.T) {
+    client := Setup(&Config{ClientId: "clientid", RedirectUri: "https://a.callback.url", Scopes: []string{"openid", "profile:read"}})
+    aurl := client.GetAuthorizationUrl("state", oauth2.SetAuthURLParam("prompt", "consent"))
+
+    assert.Contains(t, aurl, "scope=openid+profile%3Aread")
+    assert.Contains(t, aurl, "prompt=consent")
+    assert.Contains(t, aurl, "state=state")
+}
+
+func TestGrantedScopes(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        fmt.Fprint(w, `{"access_token": "accesstoken", "refresh_token": "refreshtoken", "token_type": "bearer", "expires_in": 3600, "scope": "openid profile:read"}`)
+    }))
+    defer ts.Close()
+
+    client := Setup(&Config{ClientId: "clientid", ClientSecret: "clientsecret", RedirectUri: "https://a.callback.url"})
+    client.oconf.Endpoint.TokenURL = ts.URL
+    assert.Empty(t, client.GrantedScopes())
+
+    client.GetToken(context.Background(), "code")
+    assert.Equal(t, []string{"openid", "profile:read"}, client.GrantedScopes())
+    assert.True(t, client.HasScope("profile:read"))
+    assert.False(t, client.HasScope("messages:write"))
+}
+
+func TestGrantedScopesAfterRefresh(t *testing.T) {
+    refreshes := 0
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/ping" {
+            return
+        }
+        refreshes++
+        w.Header().Set("Content-Type", "application/json")
+        fmt.Fprint(w, `{"access_token": "accesstoken", "refresh_token": "refreshtoken", "token_type": "bearer", "expires_in": 3600, "scope": "openid messages:write"}`)
+    }))
+    defer ts.Close()
+
+    client := Setup(&Config{ClientId: "clientid", ClientSecret: "clientsecret", AccessToken: "expired", RefreshToken: "refreshtoken", ExpiresAt: time.Now().Add(-time.Hour)})
+    client.oconf.Endpoint.TokenURL = ts.URL
+    assert.True(t, client.HasAccessToken(context.Background()))
+
+    // the accessor does not refresh the expired token
+    assert.Empty(t, client.GrantedScopes())
+    assert.Equal(t, 0, refreshes)
+
+    resp, err := client.oclient.Get(ts.URL + "/ping")
+    if assert.NoError(t, err) {
+        resp.Body.Close()
+    }
+    assert.Equal(t, 1, refreshes)
+    assert.True(t, client.HasScope("messages:write"))
+}
@@ -17,10 +17,13 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -36,33 +39,152 @@ type HeadersTransport struct {
 	xTenantIdHeader string
 }
 
-// Config
+// Config, the secrets are read by UnmarshalJSON but never written by json.Marshal
 type Config struct {
-	ClientId            string
-	ClientSecret        string
-	AccessToken         string
-	RefreshToken        string
-	RedirectUri         string
-	ExpiresIn           string
-	ExpiresAt           time.Time
-	State               string
-	GrantType           string
-	Debug               bool
-	HasCustomHttpClient bool
-	TenantIdHeader      string // X-Upwork-API-TenantId required for GraphQL requests
+	ClientId            string    `json:"client_id"`
+	ClientSecret        string    `json:"-"`
+	AccessToken         string    `json:"-"`
+	RefreshToken        string    `json:"-"`
+	RedirectUri         string    `json:"redirect_uri,omitempty"`
+	RevokeUrl           string    `json:"revoke_url,omitempty"`  // token revocation endpoint, RevokeTokenEP by default
+	GraphqlUrl          string    `json:"graphql_url,omitempty"` // GraphQL endpoint, GqlEndpoint by default
+	Scopes              []string  `json:"scopes,omitempty"`
+	ExpiresIn           string    `json:"expires_in,omitempty"`
+	ExpiresAt           time.Time `json:"expires_at"` // computed from IssuedAt and ExpiresIn unless given, unknown if IssuedAt is missing
+	IssuedAt            time.Time `json:"issued_at"`  // time the access token was issued at
+	State               string    `json:"state,omitempty"`
+	GrantType           string    `json:"grant_type,omitempty"`
+	Account             string    `json:"account,omitempty"` // label of the authorized user, passed to the hooks
+	Debug               bool      `json:"debug"`
+	AutoPersist         bool      `json:"auto_persist"` // save refreshed tokens back to the config file
+	HasCustomHttpClient bool      `json:"-"`
+	TenantIdHeader      string    `json:"-"` // X-Upwork-API-TenantId required for GraphQL requests
+
+	transport http.RoundTripper // base transport of the own http client, http.DefaultTransport if nil
+	sources   map[string]string // origins of the values loaded by ConfigLoader
+	file      string            // config file the values were read from
+	profile   string            // profile the values were read from
+}
+
+// ConfigError describes an invalid or missing value of a specific key
+type ConfigError struct {
+	Key    string
+	Source string // origin of the value, if loaded by ConfigLoader
+	Err    error
+}
+
+var errMissing = errors.New("is missing")
+
+func (e *ConfigError) Error() string {
+	if e.Err == errMissing {
+		return e.Key + " " + e.Err.Error()
+	}
+	if e.Source != "" {
+		return e.Key + " (from " + e.Source + "): " + e.Err.Error()
+	}
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigErrors is the list of all invalid or missing values found in a config
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the errors matches target
+func (e ConfigErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error that matches target
+func (e ConfigErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// List of supported grant types, authorization code grant is used by default
+var grantTypes = map[string]bool{
+	"":                   true,
+	"authorization_code": true,
+	"client_credentials": true,
 }
 
 // List of required configuration keys
 var requiredKeys = [2]string{"client_id", "client_secret"}
 
-// Create a new config
+// Create a new config, the invalid values are silently skipped.
+//
+// Deprecated: use ParseConfig, which reports the invalid values.
 func NewConfig(data map[string]string) (settings *Config) {
+	cfg, _ := parseConfig(data)
+
+	return cfg
+}
+
+// Create a new config, ConfigErrors listing all invalid values is returned if any.
+// This is the validating counterpart of NewConfig.
+func ParseConfig(data map[string]string) (*Config, error) {
+	cfg, errs := parseConfig(data)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return cfg, nil
+}
+
+// Parse the values and check that the required properties are defined
+func parseAndValidateConfig(data map[string]string) (*Config, error) {
+	cfg, errs := parseConfig(data)
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return cfg, nil
+}
+
+// Create a new config, the invalid values are skipped and reported
+func parseConfig(data map[string]string) (*Config, ConfigErrors) {
+	var errs ConfigErrors
 	cfg := &Config{
 		ClientId:     data["client_id"],
 		ClientSecret: data["client_secret"],
 		RedirectUri:  data["redirect_uri"],
 	}
 
+	// save revocation endpoint if defined
+	if val, ok := data["revoke_url"]; ok {
+		cfg.RevokeUrl = val
+	}
+
+	// save GraphQL endpoint if defined
+	if val, ok := data["graphql_url"]; ok {
+		cfg.GraphqlUrl = val
+	}
+
+	// save scopes if defined, separated by spaces or commas
+	if val, ok := data["scopes"]; ok {
+		cfg.Scopes = strings.Fields(strings.Replace(val, ",", " ", -1))
+	}
+
 	// save access token if defined
 	if val, ok := data["access_token"]; ok {
 		cfg.AccessToken = val
@@ -78,9 +200,31 @@ func NewConfig(data map[string]string) (settings *Config) {
 		cfg.ExpiresIn = val
 	}
 
+	// save issued_at if defined, the time expires_in is counted from
+	if val, ok := data["issued_at"]; ok && val != "" {
+		t, err := parseTime(val)
+		if err != nil {
+			errs = append(errs, &ConfigError{Key: "issued_at", Err: err})
+		}
+		cfg.IssuedAt = t
+	}
+
 	// save expiresat if defined
-	if val, ok := data["expires_at"]; ok {
-		cfg.ExpiresAt, _ = time.Parse(TIMEFORMAT, val)
+	if val, ok := data["expires_at"]; ok && val != "" {
+		t, err := parseTime(val)
+		if err != nil {
+			errs = append(errs, &ConfigError{Key: "expires_at", Err: err})
+		}
+		cfg.ExpiresAt = t
+	} else if cfg.ExpiresIn != "" {
+		// counting from the load time would move the expiry forward on every restart
+		d, err := parseExpiresIn(cfg.ExpiresIn)
+		if err != nil {
+			errs = append(errs, &ConfigError{Key: "expires_in", Err: err})
+		} else if !cfg.IssuedAt.IsZero() {
+			// without the issue time the expiry stays unknown, it would move on every load
+			cfg.ExpiresAt = cfg.IssuedAt.Add(d)
+		}
 	}
 
 	// save state if defined
@@ -93,48 +237,143 @@ func NewConfig(data map[string]string) (settings *Config) {
 		cfg.GrantType = val
 	}
 
+	// save account if defined
+	if val, ok := data["account"]; ok {
+		cfg.Account = val
+	}
+
 	// save debug flag if defined
-	if debug, ok := data["debug"]; ok && debug == "on" {
-		cfg.Debug = true
+	if val, ok := data["debug"]; ok {
+		on, err := parseSwitch(val)
+		if err != nil {
+			errs = append(errs, &ConfigError{Key: "debug", Err: err})
+		}
+		cfg.Debug = on
 	}
 
-	return cfg
+	// save auto_persist flag if defined
+	if val, ok := data["auto_persist"]; ok {
+		on, err := parseSwitch(val)
+		if err != nil {
+			errs = append(errs, &ConfigError{Key: "auto_persist", Err: err})
+		}
+		cfg.AutoPersist = on
+	}
+
+	return cfg, errs
 }
 
 // Read a specific configuration (json) file
 func ReadConfig(fn string) (settings *Config) {
-	// read from config file if exists
-	b, err := ioutil.ReadFile(fn)
+	config, err := readConfigFile(fn)
+	if err == nil {
+		settings, err = parseAndValidateConfig(config)
+	}
 	if err != nil {
-		log.Fatal("config file: ", err)
+		log.Fatal("config file: ", fn, ": ", err)
+	}
+	settings.file = fn
+
+	return settings
+}
+
+// Check that the required properties are defined
+func (cfg *Config) Validate() error {
+	if errs := cfg.validate(); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (cfg *Config) validate() ConfigErrors {
+	var errs ConfigErrors
+
+	for _, v := range requiredKeys {
+		if cfg.value(v) == "" {
+			errs = append(errs, &ConfigError{Key: v, Err: errMissing})
+		}
+	}
+
+	if cfg.RedirectUri != "" {
+		if u, err := url.Parse(cfg.RedirectUri); err != nil || !u.IsAbs() || (u.Host == "" && u.Opaque == "") {
+			errs = append(errs, &ConfigError{Key: "redirect_uri", Err: fmt.Errorf("%q is not an absolute URI", cfg.RedirectUri)})
+		}
+	} else if cfg.GrantType != "client_credentials" {
+		errs = append(errs, &ConfigError{Key: "redirect_uri", Err: errMissing})
 	}
 
-	// parse json config
+	if !grantTypes[cfg.GrantType] {
+		errs = append(errs, &ConfigError{Key: "grant_type", Err: fmt.Errorf("%q is not supported", cfg.GrantType)})
+	}
+
+	return errs
+}
+
+// Decode a config from json, the values are validated the same way as in a config file
+func (cfg *Config) UnmarshalJSON(b []byte) error {
 	var data map[string]interface{}
 	if err := json.Unmarshal(b, &data); err != nil {
-		log.Fatal("config file: ", err)
+		return err
 	}
 
-	_, ok := data["redirect_uri"]
-	if data["grant_type"] != "client_credentials" && !ok {
-		log.Fatal("config file: redirect_uri is missing in " + fn)
+	values, err := configValues(data)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseConfig(values)
+	if err != nil {
+		return err
 	}
 
-	// test required properties
-	for _, v := range requiredKeys {
-		_, ok := data[v]
-		if !ok {
-			log.Fatal("config file: " + v + " is missing in " + fn)
+	*cfg = *parsed
+	return nil
+}
+
+// Parse a point in time given in TIMEFORMAT, RFC 3339, or as unix time in seconds or milliseconds
+func parseTime(val string) (time.Time, error) {
+	for _, layout := range []string{TIMEFORMAT, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t, nil
 		}
 	}
 
-	// convert
-	config := make(map[string]string)
-	for k, v := range data {
-		config[k] = v.(string)
+	if epoch, err := strconv.ParseInt(val, 10, 64); err == nil && epoch > 0 {
+		if epoch > 1e12 {
+			return time.UnixMilli(epoch), nil
+		}
+		return time.Unix(epoch, 0), nil
 	}
 
-	return NewConfig(config)
+	return time.Time{}, fmt.Errorf("%q is neither RFC 3339 time nor unix timestamp", val)
+}
+
+// Parse a flag given as on/off or true/false
+func parseSwitch(val string) (bool, error) {
+	switch strings.ToLower(val) {
+	case "on", "true", "1":
+		return true, nil
+	case "off", "false", "0", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is neither on nor off", val)
+}
+
+// Parse a lifetime of the access token given in seconds, or as a duration like "1h"
+func parseExpiresIn(val string) (time.Duration, error) {
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		seconds, serr := strconv.ParseInt(val, 10, 64)
+		if serr != nil {
+			return 0, fmt.Errorf("%q is not a number of seconds", val)
+		}
+		d = time.Duration(seconds) * time.Second
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%q must be positive", val)
+	}
+
+	return d, nil
 }
 
 // RoundTrip for the RoundTripper interface
@@ -166,13 +405,18 @@ func (cfg *Config) SetCustomHttpClient(ctx context.Context, httpClient *http.Cli
 func (cfg *Config) SetOwnHttpClient(ctx context.Context) context.Context {
 	cfg.HasCustomHttpClient = false
 
+	rt := cfg.transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
 	// Prepare wrapper to fix User-Agent header
-	var transport http.RoundTripper = &HeadersTransport{http.DefaultTransport, UPWORK_LIBRARY_USER_AGENT, cfg.TenantIdHeader}
+	var transport http.RoundTripper = &HeadersTransport{rt, UPWORK_LIBRARY_USER_AGENT, cfg.TenantIdHeader}
 
 	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
 }
 
-// Test print of found/assigned key
+// Test print of found/assigned keys, the secrets are redacted
 func (cfg *Config) Print() {
-	fmt.Println("assigned client id (key):", cfg.ClientId)
+	fmt.Print(cfg)
 }
@@ -1,6 +1,10 @@
 package api
 
 import (
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
     "time"
     "testing"
     "github.com/stretchr/testify/assert"
@@ -22,6 +26,7 @@ func TestNewConfig(t *testing.T) {
 	"expires_at": "2018-01-01T01:00:00.000Z",
 	"expires_in": "100",
         "debug": "on",
+        "scopes": "openid, profile:read",
     }
     config := NewConfig(settings)
 
@@ -35,6 +40,7 @@ func TestNewConfig(t *testing.T) {
         assert.Equal(t, ttime, config.ExpiresAt)
         assert.Equal(t, "100", config.ExpiresIn)
         assert.True(t, true, config.Debug)
+        assert.Equal(t, []string{"openid", "profile:read"}, config.Scopes)
     }
 }
 
@@ -51,3 +57,136 @@ func TestReadConfig(t *testing.T) {
         assert.Equal(t, ttime, config.ExpiresAt)
     }
 }
+
+func TestParseConfigExpiry(t *testing.T) {
+    issuedAt := time.Date(2018, 1, 1, 1, 0, 0, 0, time.UTC)
+
+    for _, tc := range []struct {
+        data    map[string]string
+        expires time.Time
+    }{
+        {map[string]string{"expires_at": "2018-01-01T01:00:00.000Z"}, issuedAt},
+        {map[string]string{"expires_at": "2018-01-01T02:00:00+01:00"}, issuedAt},
+        {map[string]string{"expires_at": "1514768400"}, issuedAt},
+        {map[string]string{"expires_at": "1514768400000"}, issuedAt},
+        {map[string]string{"issued_at": "2018-01-01T00:00:00Z", "expires_in": "3600"}, issuedAt},
+        {map[string]string{"issued_at": "1514764800", "expires_in": "1h"}, issuedAt},
+    } {
+        config, err := ParseConfig(tc.data)
+        if assert.NoError(t, err, tc.data) {
+            assert.True(t, tc.expires.Equal(config.ExpiresAt), "%v: %v", tc.data, config.ExpiresAt)
+        }
+    }
+
+    // expiry can not be counted without the issue time, it would move on every load
+    config, err := ParseConfig(map[string]string{"expires_in": "100"})
+    if assert.NoError(t, err) {
+        assert.True(t, config.ExpiresAt.IsZero())
+        assert.Equal(t, "100", config.ExpiresIn)
+    }
+}
+
+func TestNewConfigInvalidValues(t *testing.T) {
+    // invalid values are skipped, not reported
+    config := NewConfig(map[string]string{"client_id": "consumerkey", "expires_at": "01/01/2018", "expires_in": "100", "debug": "maybe"})
+
+    if assert.NotNil(t, config) {
+        assert.Equal(t, "consumerkey", config.ClientId)
+        assert.True(t, config.ExpiresAt.IsZero())
+        assert.Equal(t, "100", config.ExpiresIn)
+        assert.False(t, config.Debug)
+    }
+}
+
+func TestParseConfigInvalidExpiry(t *testing.T) {
+    for want, data := range map[string]map[string]string{
+        "expires_at": {"expires_at": "01/01/2018"},
+        "issued_at":  {"issued_at": "yesterday", "expires_in": "100"},
+        "expires_in": {"expires_in": "soon"},
+        "positive":   {"expires_in": "-100"},
+    } {
+        _, err := ParseConfig(data)
+        if assert.Error(t, err, want) {
+            assert.Contains(t, err.Error(), want)
+        }
+    }
+}
+
+func TestReadConfigFormats(t *testing.T) {
+    dir := t.TempDir()
+    files := map[string]string{
+        "config.json": `{"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url",
+            "debug": true, "expires_in": 3600, "issued_at": 1514764800, "scopes": ["openid", "profile:read"]}`,
+        "config.yaml": `
+client_id: clientid
+client_secret: clientsecret
+redirect_uri: https://a.callback.url
+debug: true
+expires_in: 3600
+issued_at: 2018-01-01T00:00:00Z
+scopes:
+  - openid
+  - profile:read
+`,
+        "config.toml": `
+client_id = "clientid"
+client_secret = "clientsecret"
+redirect_uri = "https://a.callback.url"
+debug = true
+expires_in = 3600
+issued_at = 2018-01-01T00:00:00Z
+scopes = ["openid", "profile:read"]
+`,
+    }
+
+    for name, content := range files {
+        fn := filepath.Join(dir, name)
+        assert.NoError(t, os.WriteFile(fn, []byte(content), 0600))
+
+        config := ReadConfig(fn)
+        if assert.NotNil(t, config, name) {
+            assert.Equal(t, "clientid", config.ClientId, name)
+            assert.True(t, config.Debug, name)
+            assert.Equal(t, "3600", config.ExpiresIn, name)
+            assert.True(t, time.Date(2018, 1, 1, 1, 0, 0, 0, time.UTC).Equal(config.ExpiresAt), name)
+            assert.Equal(t, []string{"openid", "profile:read"}, config.Scopes, name)
+        }
+    }
+}
+
+func TestConfigValidationErrors(t *testing.T) {
+    config := &Config{RedirectUri: "a.callback.url", GrantType: "password"}
+
+    err := config.Validate()
+    var errs ConfigErrors
+    if assert.True(t, errors.As(err, &errs)) {
+        assert.Len(t, errs, 4)
+        assert.EqualError(t, err, `client_id is missing; client_secret is missing; redirect_uri: "a.callback.url" is not an absolute URI; grant_type: "password" is not supported`)
+    }
+
+    fn := filepath.Join(t.TempDir(), "config.json")
+    assert.NoError(t, os.WriteFile(fn, []byte(`{"client_id": "clientid", "expires_at": "never", "debug": "maybe", "state": {}}`), 0600))
+    _, err = LoadConfig(fn)
+    assert.EqualError(t, err, "config file: state: unsupported value of type map[string]interface {}")
+}
+
+func TestConfigUnmarshalJSON(t *testing.T) {
+    var config Config
+    err := json.Unmarshal([]byte(`{"client_id": "clientid", "debug": true, "expires_at": 1514768400, "scopes": ["openid"]}`), &config)
+    if assert.NoError(t, err) {
+        assert.Equal(t, "clientid", config.ClientId)
+        assert.True(t, config.Debug)
+        assert.Equal(t, []string{"openid"}, config.Scopes)
+
+        // round trip
+        b, _ := json.Marshal(&config)
+        var again Config
+        if assert.NoError(t, json.Unmarshal(b, &again)) {
+            assert.True(t, config.ExpiresAt.Equal(again.ExpiresAt))
+            assert.Equal(t, config.Scopes, again.Scopes)
+        }
+    }
+
+    err = json.Unmarshal([]byte(`{"expires_at": "never", "debug": "maybe"}`), &config)
+    assert.EqualError(t, err, `expires_at: "never" is neither RFC 3339 time nor unix timestamp; debug: "maybe" is neither on nor off`)
+}
@@ -0,0 +1,136 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Read the properties from a specific configuration file
+func readConfigFile(fn string) (map[string]string, error) {
+	data, err := decodeConfigFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return configValues(data)
+}
+
+// Decode a specific configuration file, the format is chosen by the extension:
+// .yaml or .yml for YAML, .toml for TOML, JSON otherwise
+func decodeConfigFile(fn string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	switch strings.ToLower(filepath.Ext(fn)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &data)
+	case ".toml":
+		err = toml.Unmarshal(b, &data)
+	default:
+		err = json.Unmarshal(b, &data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return data, nil
+}
+
+// Convert the decoded properties into strings, ConfigErrors is returned for the
+// known keys which values can not be converted, other keys are skipped then
+func configValues(data map[string]interface{}) (map[string]string, error) {
+	var errs ConfigErrors
+
+	known := make(map[string]bool, len(configKeys))
+	for _, k := range configKeys {
+		known[k] = true
+	}
+
+	config := make(map[string]string)
+	for k, v := range data {
+		if strings.HasPrefix(k, "__") {
+			continue // comments
+		}
+
+		s, err := configValue(k, v)
+		if err != nil {
+			if known[k] {
+				errs = append(errs, &ConfigError{Key: k, Err: err})
+			}
+			continue
+		}
+		config[k] = s
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return config, nil
+}
+
+// Convert a decoded value into a string
+func configValue(key string, v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case bool:
+		if key == "debug" {
+			if val {
+				return "on", nil
+			}
+			return "off", nil
+		}
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case uint64:
+		return strconv.FormatUint(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case time.Time:
+		return val.Format(time.RFC3339Nano), nil
+	case []interface{}:
+		// e.g. list of scopes
+		items := make([]string, len(val))
+		for i, item := range val {
+			s, err := configValue(key, item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return strings.Join(items, " "), nil
+	}
+
+	return "", fmt.Errorf("unsupported value of type %T", v)
+}
@@ -0,0 +1,202 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrDeprecated is returned by the methods of the legacy API routers
+var ErrDeprecated = errors.New("upwork: the legacy API was deprecated")
+
+// Deprecation describes a legacy router method and the GraphQL operations replacing it
+type Deprecation struct {
+	Method       string   // router package relative to api/routers and method, e.g. "hr/milestones.Create"
+	Replacements []string // GraphQL operations, e.g. "mutation createMilestoneV2", none if no replacement exists
+}
+
+// DeprecatedError is returned instead of the response by a legacy router method,
+// it matches ErrDeprecated
+type DeprecatedError struct {
+	Deprecation
+}
+
+func (e *DeprecatedError) Error() string {
+	s := "upwork: " + e.Method + ": the legacy API was deprecated"
+	if len(e.Replacements) == 0 {
+		return s + ", there is no replacement available"
+	}
+	return s + ", use GraphQL " + strings.Join(e.Replacements, " or ") + " instead"
+}
+
+func (e *DeprecatedError) Is(target error) bool {
+	return target == ErrDeprecated
+}
+
+// Create the error returned by a specific legacy router method
+func Deprecated(method string) error {
+	d, _ := LookupDeprecation(method)
+	return &DeprecatedError{d}
+}
+
+// Find the GraphQL replacement of a specific legacy router method, e.g. "messages.GetRooms"
+func LookupDeprecation(method string) (Deprecation, bool) {
+	for _, d := range deprecations {
+		if d.Method == method {
+			return d, true
+		}
+	}
+	return Deprecation{Method: method}, false
+}
+
+// List all deprecated router methods sorted by name
+func Deprecations() []Deprecation {
+	list := make([]Deprecation, len(deprecations))
+	copy(list, deprecations)
+	sort.Slice(list, func(i, j int) bool { return list[i].Method < list[j].Method })
+	return list
+}
+
+// Registry of the legacy router methods and their GraphQL replacements
+var deprecations = []Deprecation{
+	// activities
+	{"activities/engagement.Assign", []string{"mutation assignTeamActivityToContract"}},
+	{"activities/engagement.AssignToEngagement", []string{"mutation assignTeamActivityToContract"}},
+	{"activities/engagement.GetSpecific", []string{"query contractTeamActivities"}},
+	{"activities/team.AddActivity", []string{"mutation addTeamActivity"}},
+	{"activities/team.ArchiveActivity", []string{"mutation archiveTeamActivity"}},
+	{"activities/team.GetList", []string{"query teamActivities"}},
+	{"activities/team.GetSpecificList", []string{"query teamActivities"}},
+	{"activities/team.UnarchiveActivity", []string{"mutation unarchiveTeamActivity"}},
+	{"activities/team.UpdateActivity", []string{"mutation updateTeamActivity"}},
+	{"activities/team.UpdateBatch", []string{"mutation updateTeamActivity"}},
+
+	// auth
+	{"auth.GetUserInfo", []string{"query user"}},
+
+	// freelancers
+	{"freelancers/profile.GetSpecific", []string{"query freelancerProfileByProfileKey"}},
+	{"freelancers/profile.GetSpecificBrief", []string{"query freelancerProfileByProfileKey"}},
+	{"freelancers/search.Find", []string{"query freelancerProfileSearchRecords"}},
+
+	// hr
+	{"hr/clients/applications.GetList", []string{"query clientProposals"}},
+	{"hr/clients/applications.GetSpecific", []string{"query clientProposal"}},
+	{"hr/clients/offers.GetList", []string{"query clientOffers"}},
+	{"hr/clients/offers.GetSpecific", []string{"query offer"}},
+	{"hr/clients/offers.MakeOffer", []string{"mutation createOffer"}},
+	{"hr/contracts.EndContract", []string{"mutation endContract"}},
+	{"hr/contracts.RestartContract", []string{"mutation restartContract"}},
+	{"hr/contracts.SuspendContract", []string{"mutation pauseContract"}},
+	{"hr/engagements.GetList", []string{"query contractList"}},
+	{"hr/engagements.GetSpecific", []string{"query contract"}},
+	{"hr/freelancers/applications.GetList", []string{"query vendorProposals"}},
+	{"hr/freelancers/applications.GetSpecific", []string{"query vendorProposal"}},
+	{"hr/freelancers/offers.GetList", []string{"query vendorOffers"}},
+	{"hr/freelancers/offers.GetSpecific", []string{"query offer"}},
+	{"hr/freelancers/offers.MakeOffer", []string{"mutation acceptOffer", "mutation declineOffer"}},
+	{"hr/interviews.Invite", []string{"mutation inviteToInterview"}},
+	{"hr/jobs.DeleteJob", []string{"mutation closeJobPosting"}},
+	{"hr/jobs.EditJob", []string{"mutation updateJobPosting"}},
+	{"hr/jobs.GetList", []string{"query jobPostings"}},
+	{"hr/jobs.GetSpecific", []string{"query jobPosting"}},
+	{"hr/jobs.PostJob", []string{"mutation createJobPosting"}},
+	{"hr/milestones.Activate", []string{"mutation activateMilestone"}},
+	{"hr/milestones.Approve", []string{"mutation approveMilestone"}},
+	{"hr/milestones.Create", []string{"mutation createMilestoneV2"}},
+	{"hr/milestones.Delete", []string{"mutation deleteMilestone"}},
+	{"hr/milestones.Edit", []string{"mutation editMilestone"}},
+	{"hr/milestones.GetActiveMilestone", []string{"query contractMilestones"}},
+	{"hr/milestones.GetSubmissions", []string{"query milestoneSubmissions"}},
+	{"hr/roles.GetAll", []string{"query companySelector"}},
+	{"hr/roles.GetBySpecificUser", []string{"query userRoles"}},
+	{"hr/submissions.Approve", []string{"mutation approveSubmission"}},
+	{"hr/submissions.Reject", []string{"mutation rejectSubmission"}},
+	{"hr/submissions.RequestApproval", []string{"mutation requestSubmissionApproval"}},
+
+	// jobs
+	{"jobs/profile.GetSpecific", []string{"query marketplaceJobPosting"}},
+	{"jobs/search.Find", []string{"query marketplaceJobPostingsSearch"}},
+
+	// messages
+	{"messages.CreateRoom", []string{"mutation createRoomV2"}},
+	{"messages.GetRoomByApplication", []string{"query roomList"}},
+	{"messages.GetRoomByContract", []string{"query roomList"}},
+	{"messages.GetRoomByOffer", []string{"query roomList"}},
+	{"messages.GetRoomDetails", []string{"query room"}},
+	{"messages.GetRoomMessages", []string{"query roomStories"}},
+	{"messages.GetRooms", []string{"query roomList"}},
+	{"messages.SendMessageToRoom", []string{"mutation sendMessageToRoom"}},
+	{"messages.SendMessageToRooms", []string{"mutation sendMessageToRoom"}},
+	{"messages.UpdateRoomMetadata", []string{"mutation updateRoomV2"}},
+	{"messages.UpdateRoomSettings", []string{"mutation updateRoomV2"}},
+
+	// metadata
+	{"metadata.GetCategoriesV2", []string{"query ontologyCategories"}},
+	{"metadata.GetReasons", []string{"query reasons"}},
+	{"metadata.GetRegions", []string{"query regions"}},
+	{"metadata.GetSkills", []string{"query ontologySkills"}},
+	{"metadata.GetSkillsV2", []string{"query ontologySkills"}},
+	{"metadata.GetSpecialties", []string{"query ontologyCategories"}},
+	{"metadata.GetTests", nil},
+
+	// organization
+	{"organization/companies.GetList", []string{"query companySelector"}},
+	{"organization/companies.GetSpecific", []string{"query organization"}},
+	{"organization/companies.GetTeams", []string{"query organization"}},
+	{"organization/companies.GetUsers", []string{"query organization"}},
+	{"organization/teams.GetList", []string{"query companySelector"}},
+	{"organization/teams.GetUsersInTeam", []string{"query organization"}},
+	{"organization/users.GetMyInfo", []string{"query user"}},
+	{"organization/users.GetSpecific", []string{"query user"}},
+
+	// payments
+	{"payments.SubmitBonus", []string{"mutation addBonusPayment"}},
+
+	// reports
+	{"reports/finance/accounts.GetOwned", []string{"query accountingEntity"}},
+	{"reports/finance/accounts.GetSpecific", []string{"query accountingEntity"}},
+	{"reports/finance/billings.GetByBuyersCompany", []string{"query transactionHistory"}},
+	{"reports/finance/billings.GetByBuyersTeam", []string{"query transactionHistory"}},
+	{"reports/finance/billings.GetByFreelancer", []string{"query transactionHistory"}},
+	{"reports/finance/billings.GetByFreelancersCompany", []string{"query transactionHistory"}},
+	{"reports/finance/billings.GetByFreelancersTeam", []string{"query transactionHistory"}},
+	{"reports/finance/earnings.GetByBuyersCompany", []string{"query transactionHistory"}},
+	{"reports/finance/earnings.GetByBuyersTeam", []string{"query transactionHistory"}},
+	{"reports/finance/earnings.GetByFreelancer", []string{"query transactionHistory"}},
+	{"reports/finance/earnings.GetByFreelancersCompany", []string{"query transactionHistory"}},
+	{"reports/finance/earnings.GetByFreelancersTeam", []string{"query transactionHistory"}},
+	{"reports/time.GetByAgency", []string{"query timeReport"}},
+	{"reports/time.GetByCompany", []string{"query timeReport"}},
+	{"reports/time.GetByFreelancerFull", []string{"query timeReport"}},
+	{"reports/time.GetByFreelancerLimited", []string{"query timeReport"}},
+	{"reports/time.GetByTeamFull", []string{"query timeReport"}},
+	{"reports/time.GetByTeamFullLimited", []string{"query timeReport"}},
+
+	// snapshot
+	{"snapshot.DeleteByContract", nil},
+	{"snapshot.GetByContract", []string{"query workDiaryContract"}},
+	{"snapshot.UpdateByContract", nil},
+
+	// workdays
+	{"workdays.GetByCompany", []string{"query workDays"}},
+	{"workdays.GetByContract", []string{"query workDays"}},
+
+	// workdiary
+	{"workdiary.GetByCompany", []string{"query workDiaryCompany"}},
+	{"workdiary.GetByContract", []string{"query workDiaryContract"}},
+}
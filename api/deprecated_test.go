@@ -0,0 +1,80 @@
+package api
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedError(t *testing.T) {
+	err := Deprecated("hr/freelancers/offers.MakeOffer")
+
+	assert.True(t, errors.Is(err, ErrDeprecated))
+	assert.EqualError(t, err, "upwork: hr/freelancers/offers.MakeOffer: the legacy API was deprecated, use GraphQL mutation acceptOffer or mutation declineOffer instead")
+
+	var derr *DeprecatedError
+	if assert.True(t, errors.As(err, &derr)) {
+		assert.Equal(t, []string{"mutation acceptOffer", "mutation declineOffer"}, derr.Replacements)
+	}
+
+	assert.EqualError(t, Deprecated("metadata.GetTests"), "upwork: metadata.GetTests: the legacy API was deprecated, there is no replacement available")
+}
+
+// every legacy router method must be registered under its own name
+func TestDeprecationsRegistry(t *testing.T) {
+	used := make(map[string]bool)
+	fset := token.NewFileSet()
+
+	err := filepath.Walk("routers", func(fn string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() || !strings.HasSuffix(fn, ".go") || strings.HasSuffix(fn, "_test.go") {
+			return err
+		}
+		f, err := parser.ParseFile(fset, fn, nil, 0)
+		if err != nil {
+			return err
+		}
+		pkg := filepath.ToSlash(filepath.Dir(strings.TrimPrefix(fn, "routers"+string(filepath.Separator))))
+
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "Deprecated" || len(call.Args) != 1 {
+					return true
+				}
+				lit, ok := call.Args[0].(*ast.BasicLit)
+				if !ok {
+					return true
+				}
+				method, _ := strconv.Unquote(lit.Value)
+				assert.Equal(t, pkg+"."+fd.Name.Name, method, fset.Position(call.Pos()).String())
+				_, registered := LookupDeprecation(method)
+				assert.True(t, registered, method)
+				used[method] = true
+				return true
+			})
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	for _, d := range Deprecations() {
+		assert.True(t, used[d.Method], "%s is not used by any router", d.Method)
+	}
+	assert.Len(t, Deprecations(), len(deprecations))
+}
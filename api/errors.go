@@ -0,0 +1,110 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrReauthorizationRequired is returned when the refresh token was revoked or has
+// expired, the user must go through the authorization flow once again
+var ErrReauthorizationRequired = errors.New("upwork: re-authorization required")
+
+// ReauthFunc is called once the refresh token can not be used anymore. It receives
+// the account of the client and may return a fresh authorization URL, e.g. after
+// notifying a human, which is then reported in the ReauthorizationError.
+type ReauthFunc func(account string) (authUrl string)
+
+// ReauthorizationError describes a failed token refresh, it matches ErrReauthorizationRequired
+type ReauthorizationError struct {
+	Account string
+	AuthUrl string // as returned by ReauthFunc, if any
+	Err     error  // original error from the token endpoint
+}
+
+func (e *ReauthorizationError) Error() string {
+	s := ErrReauthorizationRequired.Error()
+	if e.Account != "" {
+		s += " for account " + e.Account
+	}
+	if e.AuthUrl != "" {
+		s += ", visit " + e.AuthUrl
+	}
+	return s + ": " + e.Err.Error()
+}
+
+func (e *ReauthorizationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ReauthorizationError) Is(target error) bool {
+	return target == ErrReauthorizationRequired
+}
+
+// Check if the token endpoint rejected the refresh token
+func isInvalidGrant(err error) bool {
+	var rerr *oauth2.RetrieveError
+	return errors.As(err, &rerr) && rerr.ErrorCode == "invalid_grant"
+}
+
+// reauthTokenSource converts an invalid_grant error into a ReauthorizationError. The hook
+// is called once, afterwards the same error is returned without calling the token endpoint,
+// until a valid token is found in the token store, e.g. saved by another process.
+type reauthTokenSource struct {
+	src     oauth2.TokenSource
+	store   TokenStore
+	account string
+	f       ReauthFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// Token fetches a token from the underlying source
+func (s *reauthTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		if !s.reauthorized() {
+			return nil, s.err
+		}
+		s.err = nil
+	}
+
+	t, err := s.src.Token()
+	if err != nil && isInvalidGrant(err) {
+		rerr := &ReauthorizationError{Account: s.account, Err: err}
+		if s.f != nil {
+			rerr.AuthUrl = s.f(s.account)
+		}
+		s.err = rerr
+		return nil, rerr
+	}
+
+	return t, err
+}
+
+// Check if the token store has a valid token, the account was re-authorized
+func (s *reauthTokenSource) reauthorized() bool {
+	if s.store == nil {
+		return false
+	}
+	t, err := s.store.LoadToken()
+	return err == nil && t.Valid()
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestReauthorizationRequired(t *testing.T) {
+	ts := httptest.NewServer(&tokenServer{refreshToken: "valid-refresh-token"})
+	defer ts.Close()
+
+	client := Setup(&Config{
+		ClientId:     "clientid",
+		ClientSecret: "clientsecret",
+		RedirectUri:  "https://a.callback.url",
+		AccessToken:  "accesstoken",
+		RefreshToken: "revoked-refresh-token",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+		Account:      "john",
+	})
+	client.oconf.Endpoint.TokenURL = ts.URL
+
+	var accounts []string
+	client.OnReauthRequired(func(account string) string {
+		accounts = append(accounts, account)
+		return client.GetAuthorizationUrl("new-state")
+	})
+	assert.True(t, client.HasAccessToken(context.Background()))
+
+	for _, respType := range []string{ByteResponse, ErrorResponse} {
+		client.SetApiResponseType(respType)
+		resp, re := client.Get("/ping", nil)
+		assert.Nil(t, resp)
+
+		err, ok := re.(error)
+		if assert.True(t, ok) {
+			assert.True(t, errors.Is(err, ErrReauthorizationRequired))
+			var rerr *ReauthorizationError
+			if assert.True(t, errors.As(err, &rerr)) {
+				assert.Equal(t, "john", rerr.Account)
+				assert.Contains(t, rerr.AuthUrl, "state=new-state")
+				assert.True(t, isInvalidGrant(rerr.Err))
+			}
+		}
+	}
+
+	// the hook is called only once
+	assert.Equal(t, []string{"john"}, accounts)
+}
+
+func TestReauthorizedInStore(t *testing.T) {
+	ts := httptest.NewServer(&tokenServer{refreshToken: "valid-refresh-token"})
+	defer ts.Close()
+
+	fn := filepath.Join(t.TempDir(), "token.json")
+	store := NewTokenFile(fn)
+	assert.NoError(t, store.SaveToken(&oauth2.Token{AccessToken: "accesstoken", RefreshToken: "revoked-refresh-token", Expiry: time.Now().Add(-time.Hour)}))
+
+	client := setupTokenFileClient(ts.URL, fn)
+	assert.True(t, client.HasAccessToken(context.Background()))
+
+	ping := func() (string, error) {
+		resp, err := client.oclient.Get(ts.URL + "/ping")
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return string(b), nil
+	}
+
+	_, err := ping()
+	assert.True(t, errors.Is(err, ErrReauthorizationRequired))
+	_, err = ping()
+	assert.True(t, errors.Is(err, ErrReauthorizationRequired))
+
+	// the account is re-authorized by another process
+	assert.NoError(t, store.SaveToken(&oauth2.Token{AccessToken: "new-access", RefreshToken: "valid-refresh-token", Expiry: time.Now().Add(time.Hour)}))
+	auth, err := ping()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Bearer new-access", auth)
+	}
+}
@@ -0,0 +1,48 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// in-process locks of the files, by absolute path
+var fileLocks = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{m: make(map[string]*sync.Mutex)}
+
+// Lock a specific file exclusively within this process only, there is no
+// guarantee against other processes using the same file
+func lockInProcess(fn string) (func() error, error) {
+	if abs, err := filepath.Abs(fn); err == nil {
+		fn = abs
+	}
+
+	fileLocks.Lock()
+	mu, ok := fileLocks.m[fn]
+	if !ok {
+		mu = new(sync.Mutex)
+		fileLocks.m[fn] = mu
+	}
+	fileLocks.Unlock()
+
+	mu.Lock()
+	return func() error {
+		mu.Unlock()
+		return nil
+	}, nil
+}
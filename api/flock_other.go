@@ -0,0 +1,26 @@
+//go:build !unix
+
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+// lockFile only coordinates the goroutines of this process on this platform
+const crossProcessLocking = false
+
+// Lock a specific file exclusively. Cross-process file locking is not implemented on
+// this platform, the lock only serializes the goroutines of this process.
+func lockFile(fn string) (func() error, error) {
+	return lockInProcess(fn)
+}
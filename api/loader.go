@@ -0,0 +1,263 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Origins of the configuration values
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Prefix of the environment variables, e.g. UPWORK_CLIENT_ID for client_id
+const EnvPrefix = "UPWORK_"
+
+// List of the known configuration keys
+var configKeys = []string{
+	"client_id", "client_secret", "redirect_uri", "grant_type", "scopes",
+	"access_token", "refresh_token", "expires_in", "expires_at", "issued_at",
+	"state", "account", "revoke_url", "graphql_url", "debug", "auto_persist",
+}
+
+// List of the keys, which values are redacted when printed
+var secretKeys = map[string]bool{
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+}
+
+// ConfigLoader merges configuration layers. Defaults are overridden by the
+// config file, which is overridden by the environment variables.
+type ConfigLoader struct {
+	Defaults  map[string]string
+	File      string                          // optional config file
+	Profile   string                          // profile to read if File is a profiles file, see ReadConfigProfile
+	EnvPrefix string                          // EnvPrefix if empty
+	LookupEnv func(key string) (string, bool) // os.LookupEnv if nil
+}
+
+// Load the defaults, a specific config file if it exists, and the environment variables
+func LoadConfig(fn string) (*Config, error) {
+	l := &ConfigLoader{File: fn}
+	if _, err := os.Stat(fn); os.IsNotExist(err) {
+		l.File = ""
+	}
+
+	return l.Load()
+}
+
+// Merge the layers into a config, the origin of each value is reported by Config.Source
+func (l *ConfigLoader) Load() (*Config, error) {
+	data := make(map[string]string)
+	sources := make(map[string]string)
+	set := func(values map[string]string, source string) {
+		for k, v := range values {
+			data[k] = v
+			sources[k] = source
+		}
+	}
+
+	set(l.Defaults, SourceDefault)
+
+	profile := l.profile()
+	if l.File != "" {
+		var (
+			values map[string]string
+			err    error
+		)
+		if profile != "" {
+			values, err = readConfigProfile(l.File, profile)
+		} else {
+			values, err = readConfigFile(l.File)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config file: %w", err)
+		}
+		set(values, SourceFile)
+	}
+
+	set(l.env(), SourceEnv)
+
+	cfg, err := parseAndValidateConfig(data)
+	if errs, ok := err.(ConfigErrors); ok {
+		for _, cerr := range errs {
+			cerr.Source = sources[cerr.Key]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg.sources = sources
+	if l.File != "" {
+		cfg.file, cfg.profile = l.File, profile
+	}
+
+	return cfg, nil
+}
+
+// Get the name of the profile to read, UPWORK_PROFILE is used unless set explicitly
+func (l *ConfigLoader) profile() string {
+	if l.Profile != "" {
+		return l.Profile
+	}
+	v, _ := l.lookup()(l.prefix() + "PROFILE")
+	return v
+}
+
+// Read the known keys from the environment
+func (l *ConfigLoader) env() map[string]string {
+	prefix := l.prefix()
+	lookup := l.lookup()
+
+	values := make(map[string]string)
+	for _, k := range configKeys {
+		if v, ok := lookup(prefix + strings.ToUpper(k)); ok && v != "" {
+			values[k] = v
+		}
+	}
+
+	return values
+}
+
+func (l *ConfigLoader) prefix() string {
+	if l.EnvPrefix == "" {
+		return EnvPrefix
+	}
+	return l.EnvPrefix
+}
+
+func (l *ConfigLoader) lookup() func(key string) (string, bool) {
+	if l.LookupEnv == nil {
+		return os.LookupEnv
+	}
+	return l.LookupEnv
+}
+
+// Get the origin of a specific key: SourceDefault, SourceFile, SourceEnv, or
+// an empty string if the value was not loaded by ConfigLoader
+func (cfg *Config) Source(key string) string {
+	return cfg.sources[key]
+}
+
+// Get the value of a specific key as it would be set in a config file
+func (cfg *Config) value(key string) string {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch key {
+	case "client_id":
+		return cfg.ClientId
+	case "client_secret":
+		return cfg.ClientSecret
+	case "redirect_uri":
+		return cfg.RedirectUri
+	case "grant_type":
+		return cfg.GrantType
+	case "scopes":
+		return strings.Join(cfg.Scopes, " ")
+	case "access_token":
+		return cfg.AccessToken
+	case "refresh_token":
+		return cfg.RefreshToken
+	case "expires_in":
+		return cfg.ExpiresIn
+	case "expires_at":
+		return formatTime(cfg.ExpiresAt)
+	case "issued_at":
+		return formatTime(cfg.IssuedAt)
+	case "state":
+		return cfg.State
+	case "account":
+		return cfg.Account
+	case "revoke_url":
+		return cfg.RevokeUrl
+	case "graphql_url":
+		return cfg.GraphqlUrl
+	case "debug":
+		if cfg.Debug {
+			return "on"
+		}
+		return "off"
+	case "auto_persist":
+		if cfg.AutoPersist {
+			return "on"
+		}
+		return "off"
+	}
+	return ""
+}
+
+// Format the config with the secrets redacted and the origin of each value
+func (cfg Config) String() string {
+	var b strings.Builder
+	for _, field := range cfg.fields() {
+		b.WriteString(field + "\n")
+	}
+
+	return b.String()
+}
+
+// Format the config as Go syntax with the secrets redacted
+func (cfg Config) GoString() string {
+	return "api.Config{" + strings.Join(cfg.fields(), ", ") + "}"
+}
+
+// Format the config with the secrets redacted for every verb, %#v uses GoString
+func (cfg Config) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, cfg.GoString())
+		return
+	}
+	fmt.Fprint(f, cfg.String())
+}
+
+// Get the defined values as "key: value (source)", the secrets are redacted
+func (cfg *Config) fields() []string {
+	var fields []string
+	for _, k := range configKeys {
+		v := cfg.value(k)
+		if v == "" {
+			continue
+		}
+		if secretKeys[k] {
+			v = redact(v)
+		}
+		if source := cfg.Source(k); source != "" {
+			v += " (" + source + ")"
+		}
+		fields = append(fields, k+": "+v)
+	}
+
+	return fields
+}
+
+// Hide a secret value, keeping the last characters to tell the values apart
+func redact(v string) string {
+	if len(v) <= 8 {
+		return "****"
+	}
+	return "****" + v[len(v)-4:]
+}
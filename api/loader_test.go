@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestConfigLoader(t *testing.T) {
+	l := &ConfigLoader{
+		Defaults: map[string]string{"redirect_uri": "https://default.callback.url", "debug": "on", "account": "default"},
+		File:     "../example/config.json",
+		LookupEnv: fakeEnv(map[string]string{
+			"UPWORK_CLIENT_SECRET": "secret-from-environment",
+			"UPWORK_REFRESH_TOKEN": "refresh-from-environment",
+			"UPWORK_ACCOUNT":       "",
+		}),
+	}
+
+	config, err := l.Load()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "YOUR_CONSUMER_KEY", config.ClientId)
+		assert.Equal(t, SourceFile, config.Source("client_id"))
+		assert.Equal(t, "secret-from-environment", config.ClientSecret)
+		assert.Equal(t, SourceEnv, config.Source("client_secret"))
+		assert.Equal(t, "refresh-from-environment", config.RefreshToken)
+		assert.Equal(t, "https://a.callback.url", config.RedirectUri)
+		assert.Equal(t, "default", config.Account)
+		assert.Equal(t, SourceDefault, config.Source("account"))
+		assert.False(t, config.Debug)
+		assert.Equal(t, "", config.Source("scopes"))
+
+		s := config.String()
+		assert.NotContains(t, s, "secret-from-environment")
+		assert.Contains(t, s, "client_secret: ****ment (env)")
+		assert.Contains(t, s, "client_id: YOUR_CONSUMER_KEY (file)")
+
+		for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+			for _, v := range []interface{}{config, *config} {
+				out := fmt.Sprintf(format, v)
+				assert.NotContains(t, out, "secret-from-environment", format)
+				assert.NotContains(t, out, "refresh-from-environment", format)
+				assert.Contains(t, out, "client_id: YOUR_CONSUMER_KEY", format)
+			}
+		}
+		assert.True(t, strings.HasPrefix(fmt.Sprintf("%#v", config), "api.Config{client_id: YOUR_CONSUMER_KEY (file), "))
+
+		b, err := json.Marshal(config)
+		if assert.NoError(t, err) {
+			assert.NotContains(t, string(b), "secret-from-environment")
+			assert.NotContains(t, string(b), "refresh-from-environment")
+			assert.Contains(t, string(b), `"client_id":"YOUR_CONSUMER_KEY"`)
+		}
+	}
+}
+
+func TestConfigLoaderEnvOnly(t *testing.T) {
+	l := &ConfigLoader{LookupEnv: fakeEnv(map[string]string{
+		"UPWORK_CLIENT_ID":     "clientid",
+		"UPWORK_CLIENT_SECRET": "clientsecret",
+		"UPWORK_GRANT_TYPE":    "client_credentials",
+	})}
+
+	config, err := l.Load()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "clientid", config.ClientId)
+		assert.Equal(t, "client_credentials", config.GrantType)
+	}
+}
+
+func TestConfigLoaderErrors(t *testing.T) {
+	_, err := (&ConfigLoader{LookupEnv: fakeEnv(nil)}).Load()
+	assert.EqualError(t, err, "client_id is missing; client_secret is missing; redirect_uri is missing")
+
+	l := &ConfigLoader{File: "../example/config.json", LookupEnv: fakeEnv(map[string]string{"UPWORK_EXPIRES_AT": "tomorrow"})}
+	_, err = l.Load()
+	var cerr *ConfigError
+	if assert.True(t, errors.As(err, &cerr)) {
+		assert.Equal(t, "expires_at", cerr.Key)
+		assert.Equal(t, SourceEnv, cerr.Source)
+	}
+
+	_, err = (&ConfigLoader{File: filepath.Join(t.TempDir(), "missing.json")}).Load()
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
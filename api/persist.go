@@ -0,0 +1,300 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v3"
+)
+
+// Save the token pair and its expiry (access_token, refresh_token, expires_at) into
+// a specific config file. The file is replaced atomically, its permissions and the other
+// keys are kept. If the config was read from a profile, the profile's section is updated;
+// the default section is shared by every profile, so it is never updated.
+// NOTE: comments and order of the keys are not kept in TOML files.
+func (cfg *Config) Persist(fn string) error {
+	if cfg.profile == DefaultProfile {
+		return fmt.Errorf("config file: %s: the %s section is shared by every profile, select a named profile", fn, DefaultProfile)
+	}
+
+	values := map[string]string{
+		"access_token":  cfg.AccessToken,
+		"refresh_token": cfg.RefreshToken,
+		"expires_at":    "",
+	}
+	if !cfg.ExpiresAt.IsZero() {
+		values["expires_at"] = cfg.ExpiresAt.UTC().Format(TIMEFORMAT)
+	}
+
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return err
+	}
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(fn)) {
+	case ".yaml", ".yml":
+		b, err = updateYaml(b, cfg.profile, values)
+	case ".toml":
+		b, err = updateToml(b, cfg.profile, values)
+	default:
+		b, err = updateJson(b, cfg.profile, values)
+	}
+	if err != nil {
+		return fmt.Errorf("config file: %s: %w", fn, err)
+	}
+
+	return writeFileAtomic(fn, b, fi.Mode().Perm())
+}
+
+// Save the token in the config and its file if AutoPersist is on. The file is kept
+// as is if the token pair was set by the environment, it would be overridden anyway.
+func (cfg *Config) saveToken(t *oauth2.Token) error {
+	if !cfg.AutoPersist {
+		return nil
+	}
+
+	cfg.AccessToken = t.AccessToken
+	if t.RefreshToken != "" {
+		cfg.RefreshToken = t.RefreshToken
+	}
+	cfg.ExpiresAt = t.Expiry
+
+	if cfg.tokenFromEnv() {
+		return nil
+	}
+	if err := cfg.checkAutoPersist(); err != nil {
+		return err
+	}
+
+	return cfg.Persist(cfg.file)
+}
+
+// Check that the tokens can be saved back to the config file
+func (cfg *Config) checkAutoPersist() error {
+	if cfg.file == "" {
+		return errors.New("config: auto_persist requires a config read from a file")
+	}
+	if cfg.profile == DefaultProfile {
+		return fmt.Errorf("config: auto_persist requires a named profile, the %s section is shared by every profile", DefaultProfile)
+	}
+
+	return nil
+}
+
+// Check if the token pair was set by the environment
+func (cfg *Config) tokenFromEnv() bool {
+	return cfg.Source("access_token") == SourceEnv || cfg.Source("refresh_token") == SourceEnv
+}
+
+// Remove the revoked token pair from the config, and from its file if AutoPersist is on
+func (cfg *Config) clearToken() error {
+	cfg.AccessToken, cfg.RefreshToken, cfg.ExpiresAt = "", "", time.Time{}
+	if !cfg.AutoPersist || cfg.tokenFromEnv() || cfg.checkAutoPersist() != nil {
+		return nil
+	}
+
+	return cfg.Persist(cfg.file)
+}
+
+// jsonField is a key of a json object with its raw value
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// Set the values in a json object, or in its section, keeping the order of the keys
+func updateJson(b []byte, section string, values map[string]string) ([]byte, error) {
+	fields, err := decodeJsonObject(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if section != "" {
+		for i, f := range fields {
+			if f.key == section {
+				raw, err := updateJson(f.value, "", values)
+				if err != nil {
+					return nil, fmt.Errorf("profile %s: %w", section, err)
+				}
+				fields[i].value = raw
+				return encodeJsonObject(fields)
+			}
+		}
+		return nil, fmt.Errorf("profile %s is not found", section)
+	}
+
+	for _, k := range sortedKeys(values) {
+		raw, _ := json.Marshal(values[k])
+		found := false
+		for i := range fields {
+			if fields[i].key == k {
+				fields[i].value, found = raw, true
+			}
+		}
+		if !found {
+			fields = append(fields, jsonField{k, raw})
+		}
+	}
+
+	return encodeJsonObject(fields)
+}
+
+// Decode the keys of a json object in the original order
+func decodeJsonObject(b []byte) ([]jsonField, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errors.New("json object is expected")
+	}
+
+	var fields []jsonField
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		fields = append(fields, jsonField{t.(string), raw})
+	}
+
+	return fields, nil
+}
+
+// Encode the fields as an indented json object
+func encodeJsonObject(fields []jsonField) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		k, _ := json.Marshal(f.key)
+		buf.Write(k)
+		buf.WriteString(":")
+		buf.Write(f.value)
+	}
+	buf.WriteString("}")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+
+	return out.Bytes(), nil
+}
+
+// Set the values in a yaml mapping, or in its section, keeping comments and order of the keys
+func updateYaml(b []byte, section string, values map[string]string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("yaml mapping is expected")
+	}
+
+	m := doc.Content[0]
+	if section != "" {
+		m = yamlValue(m, section)
+		if m == nil || m.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("profile %s is not found", section)
+		}
+	}
+
+	for _, k := range sortedKeys(values) {
+		if v := yamlValue(m, k); v != nil {
+			v.Kind, v.Tag, v.Value, v.Style, v.Content = yaml.ScalarNode, "!!str", values[k], 0, nil
+			continue
+		}
+		m.Content = append(m.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: values[k]})
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	enc.Close()
+
+	return buf.Bytes(), nil
+}
+
+// Find the value of a specific key in a yaml mapping
+func yamlValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Set the values in a toml document, or in its table
+func updateToml(b []byte, section string, values map[string]string) ([]byte, error) {
+	var data map[string]interface{}
+	if err := toml.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+
+	m := data
+	if section != "" {
+		var ok bool
+		if m, ok = data[section].(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("profile %s is not found", section)
+		}
+	}
+	for k, v := range values {
+		m[k] = v
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Get the keys of a map in a stable order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
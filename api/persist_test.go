@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestPersist(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.json": `{"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url", "custom": {"keep": [1, 2]}, "access_token": "old"}`,
+		"config.yaml": "# my app\nclient_id: clientid\nclient_secret: clientsecret\nredirect_uri: https://a.callback.url\ncustom: keep # comment\naccess_token: old\n",
+		"config.toml": "client_id = \"clientid\"\nclient_secret = \"clientsecret\"\nredirect_uri = \"https://a.callback.url\"\ncustom = \"keep\"\naccess_token = \"old\"\n",
+	}
+	expiry := time.Date(2030, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	for name, content := range files {
+		fn := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(fn, []byte(content), 0640))
+
+		config := ReadConfig(fn)
+		config.AccessToken, config.RefreshToken, config.ExpiresAt = "new-access", "new-refresh", expiry
+		if !assert.NoError(t, config.Persist(fn), name) {
+			continue
+		}
+
+		fi, _ := os.Stat(fn)
+		assert.Equal(t, os.FileMode(0640), fi.Mode().Perm(), name)
+
+		b, _ := os.ReadFile(fn)
+		assert.Contains(t, string(b), "keep", name)
+		assert.NotContains(t, string(b), "old", name)
+
+		again := ReadConfig(fn)
+		assert.Equal(t, "new-access", again.AccessToken, name)
+		assert.Equal(t, "new-refresh", again.RefreshToken, name)
+		assert.True(t, expiry.Equal(again.ExpiresAt), name)
+		assert.Equal(t, "clientid", again.ClientId, name)
+	}
+
+	b, _ := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	assert.True(t, strings.HasPrefix(string(b), "# my app\nclient_id: clientid\n"))
+	assert.Contains(t, string(b), "custom: keep # comment")
+}
+
+func TestPersistProfile(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "profiles.json")
+	b, _ := os.ReadFile("../example/profiles.json")
+	assert.NoError(t, os.WriteFile(fn, b, 0600))
+
+	config := ReadConfigProfile(fn, "agency")
+	config.AccessToken, config.RefreshToken = "agency-access", "agency-refresh"
+	assert.NoError(t, config.Persist(fn))
+
+	assert.Equal(t, "agency-access", ReadConfigProfile(fn, "agency").AccessToken)
+	assert.Equal(t, "", ReadConfigProfile(fn, "client").AccessToken)
+}
+
+func TestAutoPersist(t *testing.T) {
+	ts := httptest.NewServer(&tokenServer{refreshToken: "refresh-0"})
+	defer ts.Close()
+
+	fn := filepath.Join(t.TempDir(), "config.json")
+	content := `{"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url",
+		"access_token": "access-0", "refresh_token": "refresh-0", "expires_at": "2018-01-01T01:00:00.000Z", "auto_persist": true}`
+	assert.NoError(t, os.WriteFile(fn, []byte(content), 0600))
+
+	client := Setup(ReadConfig(fn))
+	client.oconf.Endpoint.TokenURL = ts.URL
+	assert.True(t, client.HasAccessToken(context.Background()))
+
+	resp, err := client.oclient.Get(ts.URL + "/ping")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	config := ReadConfig(fn)
+	assert.Equal(t, "access-1", config.AccessToken)
+	assert.Equal(t, "refresh-1", config.RefreshToken)
+	assert.True(t, config.ExpiresAt.After(time.Now()))
+	assert.True(t, config.AutoPersist)
+}
+
+func TestPersistDefaultProfile(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "profiles.json")
+	b := []byte(`{"default": {"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url"}, "agency": {}}`)
+	assert.NoError(t, os.WriteFile(fn, b, 0600))
+
+	config := ReadConfigProfile(fn, "")
+	config.AccessToken = "shared-access"
+	assert.ErrorContains(t, config.Persist(fn), "the default section is shared by every profile")
+
+	config.AutoPersist = true
+	assert.EqualError(t, config.checkAutoPersist(), "config: auto_persist requires a named profile, the default section is shared by every profile")
+
+	after, _ := os.ReadFile(fn)
+	assert.Equal(t, string(b), string(after))
+}
+
+func TestAutoPersistChecks(t *testing.T) {
+	config := &Config{ClientId: "clientid", AutoPersist: true}
+	assert.EqualError(t, config.checkAutoPersist(), "config: auto_persist requires a config read from a file")
+	assert.Error(t, config.saveToken(&oauth2.Token{AccessToken: "new-access"}))
+}
+
+func TestAutoPersistEnvToken(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "config.json")
+	content := `{"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url", "auto_persist": true}`
+	assert.NoError(t, os.WriteFile(fn, []byte(content), 0600))
+
+	env := map[string]string{EnvPrefix + "ACCESS_TOKEN": "env-access", EnvPrefix + "REFRESH_TOKEN": "env-refresh"}
+	l := &ConfigLoader{File: fn, LookupEnv: func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}}
+	config, err := l.Load()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, config.saveToken(&oauth2.Token{AccessToken: "new-access", RefreshToken: "new-refresh"}))
+	assert.Equal(t, "new-access", config.AccessToken)
+
+	b, _ := os.ReadFile(fn)
+	assert.Equal(t, content, string(b))
+}
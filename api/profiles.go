@@ -0,0 +1,96 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+// Name of the section inherited by every profile
+const DefaultProfile = "default"
+
+// Environment variable selecting a profile if no name is given
+const ProfileEnv = EnvPrefix + "PROFILE"
+
+// Read a specific profile from a profiles file, i.e. a file with named sections like
+//
+//	{
+//	  "default": {"redirect_uri": "https://a.callback.url"},
+//	  "agency": {"client_id": "...", "client_secret": "..."}
+//	}
+//
+// Every profile inherits the values of the default section. If name is empty, the
+// profile is selected by UPWORK_PROFILE environment variable, or the default one is used.
+func ReadConfigProfile(fn string, name string) (settings *Config) {
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+
+	config, err := readConfigProfile(fn, name)
+	if err == nil {
+		settings, err = parseAndValidateConfig(config)
+	}
+	if err != nil {
+		log.Fatal("config file: ", fn, ": profile ", profileName(name), ": ", err)
+	}
+	settings.file, settings.profile = fn, profileName(name)
+
+	return settings
+}
+
+// Read the properties of a specific profile merged with the default section
+func readConfigProfile(fn string, name string) (map[string]string, error) {
+	name = profileName(name)
+
+	data, err := decodeConfigFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]string)
+	for _, section := range []string{DefaultProfile, name} {
+		v, ok := data[section]
+		if !ok {
+			if section == name {
+				return nil, fmt.Errorf("profile %s is not found in %s", name, fn)
+			}
+			continue
+		}
+
+		values, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("profile %s in %s is not a section", section, fn)
+		}
+		converted, err := configValues(values)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range converted {
+			config[k] = v
+		}
+	}
+
+	return config, nil
+}
+
+// Get the name of the profile to use
+func profileName(name string) string {
+	if name == "" {
+		return DefaultProfile
+	}
+	return name
+}
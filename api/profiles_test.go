@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadConfigProfile(t *testing.T) {
+	config := ReadConfigProfile("../example/profiles.json", "agency")
+
+	if assert.NotNil(t, config) {
+		assert.Equal(t, "YOUR_AGENCY_APP_KEY", config.ClientId)
+		assert.Equal(t, "YOUR_AGENCY_APP_SECRET", config.ClientSecret)
+		assert.Equal(t, "https://agency.callback.url", config.RedirectUri)
+	}
+
+	// inherited from the default section
+	config = ReadConfigProfile("../example/profiles.json", "client")
+	if assert.NotNil(t, config) {
+		assert.Equal(t, "YOUR_CLIENT_APP_KEY", config.ClientId)
+		assert.Equal(t, "https://a.callback.url", config.RedirectUri)
+	}
+}
+
+func TestReadConfigProfileFromEnv(t *testing.T) {
+	t.Setenv(ProfileEnv, "test")
+
+	config := ReadConfigProfile("../example/profiles.json", "")
+	if assert.NotNil(t, config) {
+		assert.Equal(t, "YOUR_TEST_APP_KEY", config.ClientId)
+		assert.Equal(t, "client_credentials", config.GrantType)
+	}
+}
+
+func TestConfigLoaderProfile(t *testing.T) {
+	l := &ConfigLoader{
+		File:      "../example/profiles.json",
+		LookupEnv: fakeEnv(map[string]string{"UPWORK_PROFILE": "client", "UPWORK_CLIENT_SECRET": "secret-from-environment"}),
+	}
+
+	config, err := l.Load()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "YOUR_CLIENT_APP_KEY", config.ClientId)
+		assert.Equal(t, "secret-from-environment", config.ClientSecret)
+		assert.Equal(t, SourceFile, config.Source("redirect_uri"))
+	}
+
+	l.Profile = "missing"
+	_, err = l.Load()
+	assert.EqualError(t, err, "config file: profile missing is not found in ../example/profiles.json")
+}
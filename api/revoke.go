@@ -0,0 +1,149 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// RevocationError is returned when the revocation endpoint rejects the request,
+// see RFC 7009 section 2.2.1
+type RevocationError struct {
+	StatusCode       int
+	ErrorCode        string
+	ErrorDescription string
+}
+
+func (e *RevocationError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("upwork: token revocation failed with %d: %s %s", e.StatusCode, e.ErrorCode, e.ErrorDescription)
+	}
+	return fmt.Sprintf("upwork: token revocation failed with %d", e.StatusCode)
+}
+
+// Revoke a token (RFC 7009), the client's current token pair is used if token is nil.
+// The refresh token is revoked if known, which invalidates the access tokens issued
+// for it as well. If it is the client's own token, on success it is removed from the client,
+// the token store and, with AutoPersist on, the config file. Revoking another token leaves
+// them untouched.
+func (c *ApiClient) RevokeToken(ctx context.Context, token *oauth2.Token) error {
+	own := c.ownToken()
+	if token == nil {
+		token = own
+	}
+
+	params := url.Values{}
+	if token != nil && token.RefreshToken != "" {
+		params.Set("token", token.RefreshToken)
+		params.Set("token_type_hint", "refresh_token")
+	} else if token != nil && token.AccessToken != "" {
+		params.Set("token", token.AccessToken)
+		params.Set("token_type_hint", "access_token")
+	} else {
+		return fmt.Errorf("upwork: no token to revoke")
+	}
+	params.Set("client_id", c.config.ClientId)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.revokeUrl(), strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.config.ClientId), url.QueryEscape(c.config.ClientSecret))
+
+	if !c.hasCustomHttpClient {
+		ctx = c.config.SetOwnHttpClient(ctx)
+	}
+	httpClient, ok := ctx.Value(oauth2.HTTPClient).(*http.Client)
+	if !ok {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		rerr := &RevocationError{StatusCode: resp.StatusCode}
+		var data struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &data) == nil {
+			rerr.ErrorCode, rerr.ErrorDescription = data.Error, data.ErrorDescription
+		}
+		return rerr
+	}
+
+	// forget the revoked token pair
+	if own != nil && params.Get("token") == tokenValue(own, params.Get("token_type_hint")) {
+		c.token = &oauth2.Token{TokenType: "Bearer"}
+		c.last = nil
+		if c.oclient != nil {
+			c.setupOauth2Client(ctx)
+		}
+		if c.store != nil {
+			if err := c.store.DeleteToken(); err != nil {
+				return err
+			}
+		}
+		if err := c.config.clearToken(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get the token pair the client currently uses, a stored token is the most recent one
+// as it may have been rotated by another process
+func (c *ApiClient) ownToken() *oauth2.Token {
+	if c.store != nil {
+		if t, err := c.store.LoadToken(); err == nil {
+			return t
+		}
+	}
+	if t, ok := c.currentToken(); ok {
+		return t
+	}
+	return nil
+}
+
+// Get the value of a token by its type hint
+func tokenValue(t *oauth2.Token, hint string) string {
+	if hint == "refresh_token" {
+		return t.RefreshToken
+	}
+	return t.AccessToken
+}
+
+// URL of the revocation endpoint
+func (c *ApiClient) revokeUrl() string {
+	if c.config.RevokeUrl != "" {
+		return c.config.RevokeUrl
+	}
+	return RevokeTokenEP
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+// local revocation endpoint, follows RFC 7009
+func revocationServer(revoked *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "clientid" || secret != "clientsecret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client"}`)
+			return
+		}
+		if r.FormValue("token_type_hint") != "refresh_token" && r.FormValue("token_type_hint") != "access_token" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "unsupported_token_type"}`)
+			return
+		}
+		*revoked = append(*revoked, r.FormValue("token_type_hint")+":"+r.FormValue("token"))
+	}))
+}
+
+func TestRevokeToken(t *testing.T) {
+	var revoked []string
+	ts := revocationServer(&revoked)
+	defer ts.Close()
+
+	ctx := context.Background()
+	fn := filepath.Join(t.TempDir(), "token.json")
+	store := NewTokenFile(fn)
+	client := Setup(&Config{ClientId: "clientid", ClientSecret: "clientsecret", RevokeUrl: ts.URL})
+	client.SetTokenStore(store)
+	assert.NoError(t, store.SaveToken(&oauth2.Token{AccessToken: "accesstoken", RefreshToken: "refreshtoken"}))
+	assert.True(t, client.HasAccessToken(ctx))
+
+	// a different token does not affect the client
+	assert.NoError(t, client.RevokeToken(ctx, &oauth2.Token{AccessToken: "othertoken"}))
+	assert.Equal(t, "accesstoken", client.token.AccessToken)
+	if stored, err := store.LoadToken(); assert.NoError(t, err) {
+		assert.Equal(t, "accesstoken", stored.AccessToken)
+	}
+
+	assert.NoError(t, client.RevokeToken(ctx, nil))
+	assert.Equal(t, []string{"access_token:othertoken", "refresh_token:refreshtoken"}, revoked)
+	assert.False(t, client.HasAccessToken(ctx))
+	_, err := store.LoadToken()
+	assert.Equal(t, ErrTokenNotFound, err)
+
+	assert.Error(t, client.RevokeToken(ctx, nil))
+}
+
+func TestRevokeTokenError(t *testing.T) {
+	var revoked []string
+	ts := revocationServer(&revoked)
+	defer ts.Close()
+
+	client := Setup(&Config{ClientId: "clientid", ClientSecret: "wrongsecret", RevokeUrl: ts.URL, AccessToken: "accesstoken"})
+
+	err := client.RevokeToken(context.Background(), nil)
+	var rerr *RevocationError
+	if assert.True(t, errors.As(err, &rerr)) {
+		assert.Equal(t, http.StatusUnauthorized, rerr.StatusCode)
+		assert.Equal(t, "invalid_client", rerr.ErrorCode)
+	}
+	assert.Equal(t, "accesstoken", client.token.AccessToken)
+	assert.Empty(t, revoked)
+}
+
+func TestRevokeRefreshedToken(t *testing.T) {
+	var revoked []string
+	rs := revocationServer(&revoked)
+	defer rs.Close()
+	ts := httptest.NewServer(&tokenServer{refreshToken: "refresh-0"})
+	defer ts.Close()
+
+	fn := filepath.Join(t.TempDir(), "config.json")
+	content := `{"client_id": "clientid", "client_secret": "clientsecret", "redirect_uri": "https://a.callback.url", "revoke_url": "` + rs.URL + `",
+		"access_token": "access-0", "refresh_token": "refresh-0", "expires_at": "2018-01-01T01:00:00.000Z", "auto_persist": true}`
+	assert.NoError(t, os.WriteFile(fn, []byte(content), 0600))
+
+	ctx := context.Background()
+	client := Setup(ReadConfig(fn))
+	client.oconf.Endpoint.TokenURL = ts.URL
+	assert.True(t, client.HasAccessToken(ctx))
+
+	// the token is rotated on refresh
+	resp, err := client.oclient.Get(ts.URL + "/ping")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	assert.NoError(t, client.RevokeToken(ctx, nil))
+	assert.Equal(t, []string{"refresh_token:refresh-1"}, revoked)
+	assert.False(t, client.HasAccessToken(ctx))
+
+	config := ReadConfig(fn)
+	assert.Empty(t, config.AccessToken)
+	assert.Empty(t, config.RefreshToken)
+	assert.True(t, config.ExpiresAt.IsZero())
+}
@@ -37,15 +37,15 @@ func New(c *api.ApiClient) *a {
 
 // List activities for specific engagement
 func (r a) GetSpecific(engagementRef string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/engagement.GetSpecific")
 }
 
 // Assign engagements to the list of activities
 func (r a) Assign(company string, team string, engagement string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/engagement.Assign")
 }
 
 // Assign to specific engagement the list of activities
 func (r a) AssignToEngagement(engagementRef string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/engagement.AssignToEngagement")
 }
@@ -37,48 +37,35 @@ func New(c *api.ApiClient) *a {
 
 // List all oTask/Activity records within a team
 func (r a) GetList(company string, team string, params ...map[string]string) (*http.Response, interface{}) {
-	var p map[string]string
-	if params != nil {
-		p = params[0]
-	}
-	return r.getByType(company, team, "", p)
+	return nil, api.Deprecated("activities/team.GetList")
 }
 
 // List all oTask/Activity records within a team by specified code(s)
 func (r a) GetSpecificList(company string, team string, code string, params ...map[string]string) (*http.Response, interface{}) {
-	var p map[string]string
-	if params != nil {
-		p = params[0]
-	}
-	return r.getByType(company, team, code, p)
+	return nil, api.Deprecated("activities/team.GetSpecificList")
 }
 
 // Create an oTask/Activity record within a team
 func (r a) AddActivity(company string, team string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/team.AddActivity")
 }
 
 // Update specific oTask/Activity record within a team
 func (r a) UpdateActivity(company string, team string, code string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/team.UpdateActivity")
 }
 
 // Archive specific oTask/Activity record within a team
 func (r a) ArchiveActivity(company string, team string, code string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/team.ArchiveActivity")
 }
 
 // Unarchive specific oTask/Activity record within a team
 func (r a) UnarchiveActivity(company string, team string, code string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/team.UnarchiveActivity")
 }
 
 // Update a group of oTask/Activity records
 func (r a) UpdateBatch(company string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
-}
-
-// Get by type
-func (r a) getByType(company string, team string, code string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("activities/team.UpdateBatch")
 }
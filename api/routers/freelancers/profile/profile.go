@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get specific Freelancer's Profile
 func (r a) GetSpecific(key string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("freelancers/profile.GetSpecific")
 }
 
 // Get brief info for the specific Freelancer's Profile
 func (r a) GetSpecificBrief(key string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("freelancers/profile.GetSpecificBrief")
 }
@@ -37,5 +37,5 @@ func New(c *api.ApiClient) *a {
 
 // Search freelancers
 func (r a) Find(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("freelancers/search.Find")
 }
@@ -0,0 +1,176 @@
+// Local stand-in of Upwork GraphQL API for tests
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2021(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+
+// Package graphqltest provides a local GraphQL server, which dispatches the
+// requests by their operation name, for testing the typed services.
+package graphqltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Access token of the client returned by Server.Client
+const AccessToken = "graphqltest-access-token"
+
+var operationRe = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// Request received by the server
+type Request struct {
+	Operation string // name of the operation, e.g. "roomList"
+	Query     string
+	Variables map[string]interface{}
+	TenantId  string // X-Upwork-API-TenantId header
+
+	raw json.RawMessage
+}
+
+// Decode the variables of the request into v
+func (r Request) Decode(v interface{}) error {
+	return json.Unmarshal(r.raw, v)
+}
+
+// Handler of an operation, returns the data of the response, a *graphql.Error
+// or graphql.Errors are reported as is, other errors as a single error
+type Handler func(req Request) (data interface{}, err error)
+
+// Server is a local GraphQL server
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	requests []Request
+}
+
+// Start a new server, which is closed when the test finishes
+func NewServer(t testing.TB) *Server {
+	s := &Server{handlers: make(map[string]Handler)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Register the handler of an operation
+func (s *Server) Handle(operation string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[operation] = h
+}
+
+// Respond to an operation with a fixed data, given as a JSON string
+func (s *Server) Respond(operation string, data string) {
+	s.Handle(operation, func(Request) (interface{}, error) {
+		return Raw(data), nil
+	})
+}
+
+// Get a JSON string to be returned by a handler as is
+func Raw(data string) json.RawMessage {
+	return json.RawMessage(data)
+}
+
+// Get the received requests of an operation
+func (s *Server) Requests(operation string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reqs []Request
+	for _, r := range s.requests {
+		if r.Operation == operation {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+// Get an authorized client sending GraphQL requests to the server
+func (s *Server) Client() *api.ApiClient {
+	client := api.Setup(&api.Config{
+		ClientId:     "clientid",
+		ClientSecret: "clientsecret",
+		RedirectUri:  "https://a.callback.url",
+		AccessToken:  AccessToken,
+		RefreshToken: "graphqltest-refresh-token",
+		GraphqlUrl:   s.URL,
+	})
+	client.HasAccessToken(context.Background())
+
+	return &client
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+AccessToken {
+		http.Error(w, `{"message": "unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		Query     string          `json:"query"`
+		Variables json.RawMessage `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := Request{Query: body.Query, TenantId: r.Header.Get("X-Upwork-API-TenantId"), raw: body.Variables}
+	if len(req.raw) == 0 {
+		req.raw = json.RawMessage("{}")
+	}
+	json.Unmarshal(req.raw, &req.Variables)
+	if m := operationRe.FindStringSubmatch(body.Query); m != nil {
+		req.Operation = m[1]
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	h, ok := s.handlers[req.Operation]
+	s.mu.Unlock()
+
+	var resp struct {
+		Data   interface{}    `json:"data"`
+		Errors graphql.Errors `json:"errors,omitempty"`
+	}
+	if !ok {
+		resp.Errors = graphql.Errors{{Message: fmt.Sprintf("unknown operation %q", req.Operation)}}
+	} else {
+		data, err := h(req)
+		resp.Data = data
+		switch err := err.(type) {
+		case nil:
+		case *graphql.Error:
+			resp.Errors = graphql.Errors{err}
+		case graphql.Errors:
+			resp.Errors = err
+		default:
+			resp.Errors = graphql.Errors{{Message: err.Error()}}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
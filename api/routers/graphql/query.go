@@ -0,0 +1,181 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2021(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+)
+
+// Default size of a page requested by the typed services
+const DefaultPageSize = 50
+
+// ErrNotFound is returned by the typed services if the requested object does not exist
+var ErrNotFound = errors.New("upwork: graphql: not found")
+
+// GraphQL request
+type Request struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Error reported by the GraphQL endpoint
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) > 0 {
+		path := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			path[i] = fmt.Sprint(p)
+		}
+		return fmt.Sprintf("upwork: graphql: %s: %s", strings.Join(path, "."), e.Message)
+	}
+	return "upwork: graphql: " + e.Message
+}
+
+// Get the code of the error from its extensions, e.g. "UNAUTHORIZED", if any
+func (e *Error) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// Errors reported by the GraphQL endpoint for a single request
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// StatusError is returned when the GraphQL endpoint responds with a non-200 status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("upwork: graphql: unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// Pagination of a connection, the first page is requested if After is empty
+type Pagination struct {
+	First int    `json:"first"`
+	After string `json:"after,omitempty"`
+}
+
+// Get the pagination with the default page size if it is not set
+func (p Pagination) OrDefault() Pagination {
+	if p.First <= 0 {
+		p.First = DefaultPageSize
+	}
+	return p
+}
+
+// Page information of a connection
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// Get the pagination of the next page
+func (p PageInfo) Next(first int) Pagination {
+	return Pagination{First: first, After: p.EndCursor}
+}
+
+// Connection of nodes of a specific type, requested as
+// { totalCount edges { node { ... } } pageInfo { hasNextPage endCursor } }
+type Connection[T any] struct {
+	TotalCount int `json:"totalCount"`
+	Edges      []struct {
+		Node T `json:"node"`
+	} `json:"edges"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// Get the nodes of the connection, an empty slice if there are none
+func (c Connection[T]) Nodes() []T {
+	nodes := make([]T, 0, len(c.Edges))
+	for _, e := range c.Edges {
+		nodes = append(nodes, e.Node)
+	}
+	return nodes
+}
+
+// Execute a typed GraphQL request and decode the data of the response into out.
+// The data is decoded even if the endpoint reports Errors along with it.
+func (r a) Query(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	return Do(ctx, r.client, query, variables, out)
+}
+
+// Execute a typed GraphQL request using a specific client, see Query
+func Do(ctx context.Context, c *api.ApiClient, query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.GraphqlUrl(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors Errors          `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("upwork: graphql: invalid response: %w", err)
+	}
+	if out != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, out); err != nil {
+			return fmt.Errorf("upwork: graphql: invalid response: %w", err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors
+	}
+
+	return nil
+}
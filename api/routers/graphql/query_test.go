@@ -0,0 +1,28 @@
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnection(t *testing.T) {
+	type node struct {
+		Id string `json:"id"`
+	}
+
+	var c Connection[node]
+	b := `{"totalCount": 3, "edges": [{"node": {"id": "1"}}, {"node": {"id": "2"}}], "pageInfo": {"hasNextPage": true, "endCursor": "2"}}`
+	if assert.NoError(t, json.Unmarshal([]byte(b), &c)) {
+		assert.Equal(t, []node{{"1"}, {"2"}}, c.Nodes())
+		assert.Equal(t, 3, c.TotalCount)
+		assert.Equal(t, Pagination{First: 2, After: "2"}, c.PageInfo.Next(2))
+	}
+
+	// no edges, e.g. if the connection is null
+	var empty Connection[node]
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &empty))
+	assert.NotNil(t, empty.Nodes())
+	assert.Empty(t, empty.Nodes())
+}
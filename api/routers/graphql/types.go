@@ -0,0 +1,223 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2021(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default currency of the amounts
+const DefaultCurrency = "USD"
+
+// Layout of a Date
+const DateFormat = "2006-01-02"
+
+var (
+	amountRe   = regexp.MustCompile(`^(-?)(\d+)(?:\.(\d{1,2}))?$`)
+	currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Money is an amount in a specific currency, kept in cents to avoid rounding errors
+type Money struct {
+	Cents    int64
+	Currency string // ISO 4217 code, DefaultCurrency if empty
+}
+
+// Create an amount from a decimal string, e.g. "100.50"
+func NewMoney(amount string, currency string) (Money, error) {
+	m := amountRe.FindStringSubmatch(strings.TrimSpace(amount))
+	if m == nil {
+		return Money{}, fmt.Errorf("upwork: invalid amount %q", amount)
+	}
+	units, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("upwork: invalid amount %q", amount)
+	}
+	cents, _ := strconv.ParseInt((m[3] + "00")[:2], 10, 64)
+	money := Money{Cents: units*100 + cents, Currency: currency}
+	if m[1] == "-" {
+		money.Cents = -money.Cents
+	}
+	return money, nil
+}
+
+// Create an amount in USD from cents
+func USD(cents int64) Money {
+	return Money{Cents: cents, Currency: "USD"}
+}
+
+// Get the currency, DefaultCurrency if not set
+func (m Money) CurrencyCode() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// Check if the amount is zero
+func (m Money) IsZero() bool {
+	return m.Cents == 0
+}
+
+// Format the amount as a decimal string, e.g. "100.50"
+func (m Money) Amount() string {
+	sign, cents := "", m.Cents
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (m Money) String() string {
+	return m.Amount() + " " + m.CurrencyCode()
+}
+
+// Check that the amount is positive and the currency is valid, the error is a *ValidationError without a field
+func (m Money) Validate() error {
+	if !currencyRe.MatchString(m.CurrencyCode()) {
+		return &ValidationError{Message: fmt.Sprintf("invalid currency %q", m.Currency)}
+	}
+	if m.Cents <= 0 {
+		return &ValidationError{Message: fmt.Sprintf("amount must be positive, got %s", m)}
+	}
+	return nil
+}
+
+// Check that both amounts are in the same currency
+func (m Money) SameCurrency(o Money) bool {
+	return m.CurrencyCode() == o.CurrencyCode()
+}
+
+// Encode as the MoneyInput of GraphQL API
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"rawValue": m.Amount(), "currency": m.CurrencyCode()})
+}
+
+// Decode the Money of GraphQL API
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var data struct {
+		RawValue json.Number `json:"rawValue"`
+		Currency string      `json:"currency"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	if data.RawValue == "" {
+		*m = Money{Currency: data.Currency}
+		return nil
+	}
+
+	// the raw value can have more decimals, e.g. "10.000"
+	f, err := strconv.ParseFloat(string(data.RawValue), 64)
+	if err != nil {
+		return fmt.Errorf("upwork: invalid amount %q", data.RawValue)
+	}
+	*m = Money{Cents: int64(f*100 + sign(f)*0.5), Currency: data.Currency}
+	return nil
+}
+
+func sign(f float64) float64 {
+	if f < 0 {
+		return -1
+	}
+	return 1
+}
+
+// Date without time, e.g. a due date
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// Parse a date in DateFormat
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateFormat, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("upwork: invalid date %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// Get the date of a specific time in its location
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+// Check if the date is not set
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Check if the date is before another one
+func (d Date) Before(o Date) bool {
+	return d.Time().Before(o.Time())
+}
+
+// Get the midnight of the date in UTC
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Time().Format(DateFormat)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// Decode a date, the API returns either DateFormat, RFC 3339 or epoch milliseconds
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if string(b) == "null" {
+		*d = Date{}
+		return nil
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		s = string(b)
+	}
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		*d = DateOf(t.UTC())
+		return nil
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*d = DateOf(time.UnixMilli(ms).UTC())
+		return nil
+	}
+	date, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
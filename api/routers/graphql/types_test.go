@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney(t *testing.T) {
+	for amount, cents := range map[string]int64{"100": 10000, "100.5": 10050, "0.05": 5, "-1.25": -125} {
+		m, err := NewMoney(amount, "EUR")
+		if assert.NoError(t, err, amount) {
+			assert.Equal(t, cents, m.Cents, amount)
+		}
+	}
+	for _, amount := range []string{"", "1.234", "1,5", "abc"} {
+		_, err := NewMoney(amount, "USD")
+		assert.Error(t, err, amount)
+	}
+
+	assert.Equal(t, "100.50 USD", USD(10050).String())
+	assert.NoError(t, USD(1).Validate())
+	assert.Error(t, USD(0).Validate())
+	assert.Error(t, Money{Cents: 1, Currency: "usd"}.Validate())
+	assert.True(t, Money{Cents: 1}.SameCurrency(USD(2)))
+
+	b, err := json.Marshal(Money{Cents: 1999, Currency: "EUR"})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"rawValue": "19.99", "currency": "EUR"}`, string(b))
+	}
+
+	var m Money
+	if assert.NoError(t, json.Unmarshal([]byte(`{"rawValue": "19.990", "currency": "USD", "displayValue": "$19.99"}`), &m)) {
+		assert.Equal(t, USD(1999), m)
+	}
+	if assert.NoError(t, json.Unmarshal([]byte(`{"rawValue": 0.29, "currency": "USD"}`), &m)) {
+		assert.Equal(t, USD(29), m)
+	}
+}
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2023-06-01")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Date{2023, time.June, 1}, d)
+	}
+	_, err = ParseDate("06/01/2023")
+	assert.Error(t, err)
+
+	for _, s := range []string{`"2023-06-01"`, `"2023-06-01T10:00:00Z"`, `"1685613600000"`} {
+		var d Date
+		if assert.NoError(t, json.Unmarshal([]byte(s), &d), s) {
+			assert.Equal(t, Date{2023, time.June, 1}, d, s)
+		}
+	}
+
+	b, _ := json.Marshal(struct {
+		Due  Date `json:"due"`
+		Zero Date `json:"zero"`
+	}{Due: Date{2023, time.June, 1}})
+	assert.JSONEq(t, `{"due": "2023-06-01", "zero": null}`, string(b))
+	assert.True(t, Date{2023, time.May, 31}.Before(Date{2023, time.June, 1}))
+}
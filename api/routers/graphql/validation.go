@@ -0,0 +1,83 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2021(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes an invalid field of an input, found before sending it
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		// an invalid value, e.g. reported by Money.Validate
+		return "upwork: " + e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors are all invalid fields of an input
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "upwork: invalid input: " + strings.Join(msgs, "; ")
+}
+
+// Add an invalid field
+func (e *ValidationErrors) Add(field string, format string, args ...interface{}) {
+	*e = append(*e, &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Add an invalid field reported by an error, a ValidationError keeps its message
+// and the field it names is nested in field
+func (e *ValidationErrors) AddErr(field string, err error) {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		e.Add(field, "%s", err.Error())
+		return
+	}
+	if verr.Field != "" {
+		field += "." + verr.Field
+	}
+	e.Add(field, "%s", verr.Message)
+}
+
+// Check if a specific field is invalid
+func (e ValidationErrors) Has(field string) bool {
+	for _, err := range e {
+		if err.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the errors as an error, nil if there are none
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,23 @@
+package graphql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	assert.NoError(t, errs.Err())
+
+	errs.Add("title", "is missing")
+	errs.AddErr("budget", USD(0).Validate())
+	errs.AddErr("rate", &ValidationError{Field: "currency", Message: "is unknown"})
+	errs.AddErr("date", errors.New("not a date"))
+
+	assert.True(t, errs.Has("budget"))
+	assert.True(t, errs.Has("rate.currency"))
+	assert.EqualError(t, errs.Err(), "upwork: invalid input: title: is missing; budget: amount must be positive, got 0.00 USD; rate.currency: is unknown; date: not a date")
+	assert.EqualError(t, Money{Cents: 1, Currency: "usd1"}.Validate(), `upwork: invalid currency "usd1"`)
+}
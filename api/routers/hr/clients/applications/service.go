@@ -0,0 +1,358 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/messages"
+)
+
+// ErrInvalidTransition is matched by the errors of the actions not allowed in the proposal's status
+var ErrInvalidTransition = errors.New("upwork: applications: invalid status transition")
+
+// Status of a proposal
+type Status string
+
+const (
+	StatusActive      Status = "ACTIVE" // new, not reviewed yet
+	StatusShortlisted Status = "SHORTLISTED"
+	StatusArchived    Status = "ARCHIVED"
+	StatusDeclined    Status = "DECLINED"
+	StatusHired       Status = "HIRED"
+	StatusWithdrawn   Status = "WITHDRAWN" // by the freelancer
+)
+
+// Action of a client on a proposal
+type Action string
+
+const (
+	ActionShortlist Action = "shortlist"
+	ActionArchive   Action = "archive"
+	ActionDecline   Action = "decline"
+)
+
+// State machine of a proposal, the status after an action allowed in a specific status
+var transitions = map[Status]map[Action]Status{
+	StatusActive:      {ActionShortlist: StatusShortlisted, ActionArchive: StatusArchived, ActionDecline: StatusDeclined},
+	StatusShortlisted: {ActionArchive: StatusArchived, ActionDecline: StatusDeclined},
+	StatusArchived:    {ActionShortlist: StatusShortlisted, ActionDecline: StatusDeclined},
+	StatusDeclined:    {},
+	StatusHired:       {},
+	StatusWithdrawn:   {},
+}
+
+// TransitionError is returned if an action is not allowed in the proposal's status
+type TransitionError struct {
+	ProposalId string
+	Status     Status
+	Action     Action
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("upwork: applications: can not %s proposal %s in status %s", e.Action, e.ProposalId, e.Status)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Reason of declining a proposal
+type DeclineReason string
+
+const (
+	ReasonBudget       DeclineReason = "BUDGET"
+	ReasonSkills       DeclineReason = "SKILLS_MISMATCH"
+	ReasonExperience   DeclineReason = "NOT_ENOUGH_EXPERIENCE"
+	ReasonHiredOther   DeclineReason = "HIRED_SOMEONE_ELSE"
+	ReasonJobCancelled DeclineReason = "JOB_CANCELLED"
+	ReasonDeclineOther DeclineReason = "OTHER"
+)
+
+var declineReasons = map[DeclineReason]bool{
+	ReasonBudget: true, ReasonSkills: true, ReasonExperience: true,
+	ReasonHiredOther: true, ReasonJobCancelled: true, ReasonDeclineOther: true,
+}
+
+// Summary of the freelancer who submitted a proposal
+type Freelancer struct {
+	Id              string        `json:"id"` // profile
+	UserId          string        `json:"userId"`
+	Name            string        `json:"name"`
+	Title           string        `json:"title"`
+	Country         string        `json:"country"`
+	HourlyRate      graphql.Money `json:"hourlyRate"`
+	JobSuccessScore int           `json:"jobSuccessScore"` // percent, 0 if not rated yet
+	TotalHours      int           `json:"totalHours"`
+	TopRated        bool          `json:"topRated"`
+}
+
+// Answer to a screening question of the job posting
+type Answer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// Proposal received on a job posting
+type Proposal struct {
+	Id           string        `json:"id"`
+	JobPostingId string        `json:"jobPostingId"`
+	Status       Status        `json:"status"`
+	Freelancer   Freelancer    `json:"freelancer"`
+	CoverLetter  string        `json:"coverLetter"`
+	Bid          graphql.Money `json:"bid"` // hourly rate or fixed amount
+	Answers      []Answer      `json:"screeningAnswers"`
+	CreatedAt    time.Time     `json:"createdDateTime"`
+}
+
+// Check if an action is allowed in the proposal's status
+func (p Proposal) Can(action Action) error {
+	if _, ok := transitions[p.Status][action]; !ok {
+		return &TransitionError{ProposalId: p.Id, Status: p.Status, Action: action}
+	}
+	return nil
+}
+
+// Field to sort the proposals by
+type SortField string
+
+const (
+	SortCreated         SortField = "CREATED_DATE_TIME"
+	SortBid             SortField = "BID"
+	SortJobSuccessScore SortField = "JOB_SUCCESS_SCORE"
+	SortTotalHours      SortField = "TOTAL_HOURS"
+)
+
+// Order of the proposal list, by the server's relevance if Field is empty
+type Sort struct {
+	Field      SortField
+	Descending bool
+}
+
+// Filter of the proposal list, empty fields are not applied
+type Filter struct {
+	Statuses           []Status
+	BidMin             graphql.Money
+	BidMax             graphql.Money
+	MinJobSuccessScore int
+	Countries          []string
+	TopRatedOnly       bool
+}
+
+// Page of proposals
+type Page struct {
+	Proposals  []Proposal
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Proposal review service of a client based on GraphQL API
+type Service struct {
+	client   *api.ApiClient
+	messages *messages.Service
+}
+
+const proposalFields = `id jobPostingId status
+	freelancer { id userId name title country hourlyRate { rawValue currency } jobSuccessScore totalHours topRated }
+	coverLetter bid { rawValue currency } screeningAnswers { question answer } createdDateTime`
+
+const clientProposalsQuery = `query clientProposals($jobPostingId: ID!, $filter: ClientProposalFilter, $sortAttribute: ClientProposalSortAttribute, $pagination: Pagination) {
+  clientProposals(jobPostingId: $jobPostingId, filter: $filter, sortAttribute: $sortAttribute, pagination: $pagination) {
+    totalCount
+    edges { node { ` + proposalFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const clientProposalQuery = `query clientProposal($id: ID!) {
+  clientProposal(id: $id) { ` + proposalFields + ` }
+}`
+
+const shortlistMutation = `mutation shortlistProposal($input: ProposalActionInput!) {
+  shortlistProposal(input: $input) { ` + proposalFields + ` }
+}`
+
+const archiveMutation = `mutation archiveProposal($input: ProposalActionInput!) {
+  archiveProposal(input: $input) { ` + proposalFields + ` }
+}`
+
+const declineMutation = `mutation declineProposal($input: DeclineProposalInput!) {
+  declineProposal(input: $input) { ` + proposalFields + ` }
+}`
+
+var mutations = map[Action]struct{ query, field string }{
+	ActionShortlist: {shortlistMutation, "shortlistProposal"},
+	ActionArchive:   {archiveMutation, "archiveProposal"},
+	ActionDecline:   {declineMutation, "declineProposal"},
+}
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c, messages.NewService(c)}
+}
+
+// List the proposals of a job posting
+func (s *Service) List(ctx context.Context, jobPostingId string, filter Filter, sort Sort, page graphql.Pagination) (*Page, error) {
+	if jobPostingId == "" {
+		return nil, fmt.Errorf("upwork: applications: job posting ID is missing")
+	}
+
+	var data struct {
+		ClientProposals graphql.Connection[Proposal] `json:"clientProposals"`
+	}
+	vars := map[string]interface{}{
+		"jobPostingId": jobPostingId,
+		"filter":       filter.variables(),
+		"pagination":   page.OrDefault(),
+	}
+	if sort.Field != "" {
+		vars["sortAttribute"] = sort.variables()
+	}
+	if err := graphql.Do(ctx, s.client, clientProposalsQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.ClientProposals
+	return &Page{Proposals: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get a specific proposal
+func (s *Service) Get(ctx context.Context, id string) (*Proposal, error) {
+	var data struct {
+		ClientProposal *Proposal `json:"clientProposal"`
+	}
+	if err := graphql.Do(ctx, s.client, clientProposalQuery, map[string]interface{}{"id": id}, &data); err != nil {
+		return nil, err
+	}
+	if data.ClientProposal == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.ClientProposal, nil
+}
+
+// Shortlist a proposal
+func (s *Service) Shortlist(ctx context.Context, p Proposal) (*Proposal, error) {
+	return s.act(ctx, p, ActionShortlist, map[string]interface{}{"proposalId": p.Id})
+}
+
+// Archive a proposal
+func (s *Service) Archive(ctx context.Context, p Proposal) (*Proposal, error) {
+	return s.act(ctx, p, ActionArchive, map[string]interface{}{"proposalId": p.Id})
+}
+
+// Decline a proposal, the message to the freelancer is optional
+func (s *Service) Decline(ctx context.Context, p Proposal, reason DeclineReason, message string) (*Proposal, error) {
+	if !declineReasons[reason] {
+		return nil, fmt.Errorf("upwork: applications: invalid reason %q", reason)
+	}
+
+	input := map[string]interface{}{"proposalId": p.Id, "reason": reason}
+	if message != "" {
+		input["message"] = message
+	}
+	return s.act(ctx, p, ActionDecline, input)
+}
+
+// Send a message to the freelancer in the room of a proposal, the room is created if it does not exist yet,
+// which requires the freelancer's user ID
+func (s *Service) Message(ctx context.Context, p Proposal, message string) (*messages.Story, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, fmt.Errorf("upwork: applications: the message is empty")
+	}
+
+	rooms, err := s.messages.ListRooms(ctx, messages.RoomFilter{ApplicationId: p.Id}, graphql.Pagination{First: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	var roomId string
+	if len(rooms.Rooms) > 0 {
+		roomId = rooms.Rooms[0].Id
+	} else {
+		if p.Freelancer.UserId == "" {
+			return nil, fmt.Errorf("upwork: applications: proposal %s has no freelancer user ID, the room can not be created", p.Id)
+		}
+		name := p.Freelancer.Name
+		if name == "" {
+			name = "Proposal " + p.Id
+		}
+		room, err := s.messages.CreateRoom(ctx, messages.NewRoom{RoomName: name, ApplicationId: p.Id, UserIds: []string{p.Freelancer.UserId}})
+		if err != nil {
+			return nil, err
+		}
+		roomId = room.Id
+	}
+	return s.messages.SendMessage(ctx, roomId, message)
+}
+
+// Run an action on a proposal
+func (s *Service) act(ctx context.Context, p Proposal, action Action, input map[string]interface{}) (*Proposal, error) {
+	if err := p.Can(action); err != nil {
+		return nil, err
+	}
+
+	m := mutations[action]
+	var data map[string]*Proposal
+	if err := graphql.Do(ctx, s.client, m.query, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data[m.field] == nil {
+		return nil, fmt.Errorf("upwork: applications: %s returned no proposal", m.field)
+	}
+	return data[m.field], nil
+}
+
+// Get the ClientProposalSortAttribute input
+func (s Sort) variables() map[string]interface{} {
+	order := "ASC"
+	if s.Descending {
+		order = "DESC"
+	}
+	return map[string]interface{}{"field": s.Field, "sortOrder": order}
+}
+
+// Get the ClientProposalFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if len(f.Statuses) > 0 {
+		vars["status_any"] = f.Statuses
+	}
+	if !f.BidMin.IsZero() || !f.BidMax.IsZero() {
+		bid := make(map[string]interface{})
+		if !f.BidMin.IsZero() {
+			bid["rangeStart"] = f.BidMin
+		}
+		if !f.BidMax.IsZero() {
+			bid["rangeEnd"] = f.BidMax
+		}
+		vars["bid_bt"] = bid
+	}
+	if f.MinJobSuccessScore > 0 {
+		vars["jobSuccessScore_gte"] = f.MinJobSuccessScore
+	}
+	if len(f.Countries) > 0 {
+		vars["country_any"] = f.Countries
+	}
+	if f.TopRatedOnly {
+		vars["topRated_eq"] = true
+	}
+	return vars
+}
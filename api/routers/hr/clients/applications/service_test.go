@@ -0,0 +1,121 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("clientProposals", `{"clientProposals": {"totalCount": 1, "edges": [{"node": {"id": "p1", "status": "ACTIVE", "coverLetter": "Hi",
+		"freelancer": {"id": "~01", "name": "Jane", "jobSuccessScore": 97, "topRated": true},
+		"bid": {"rawValue": "55", "currency": "USD"}, "screeningAnswers": [{"question": "Why?", "answer": "Because"}]}}]}}`)
+	service := NewService(srv.Client())
+
+	_, err := service.List(context.Background(), "", Filter{}, Sort{}, graphql.Pagination{})
+	assert.Error(t, err)
+
+	filter := Filter{Statuses: []Status{StatusActive, StatusShortlisted}, BidMax: graphql.USD(6000), MinJobSuccessScore: 90, TopRatedOnly: true}
+	page, err := service.List(context.Background(), "j1", filter, Sort{Field: SortBid, Descending: true}, graphql.Pagination{})
+	if assert.NoError(t, err) && assert.Len(t, page.Proposals, 1) {
+		p := page.Proposals[0]
+		assert.Equal(t, "Jane", p.Freelancer.Name)
+		assert.Equal(t, 97, p.Freelancer.JobSuccessScore)
+		assert.Equal(t, graphql.USD(5500), p.Bid)
+		assert.Equal(t, []Answer{{Question: "Why?", Answer: "Because"}}, p.Answers)
+	}
+	if reqs := srv.Requests("clientProposals"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, "j1", reqs[0].Variables["jobPostingId"])
+		assert.Equal(t, map[string]interface{}{
+			"status_any":          []interface{}{"ACTIVE", "SHORTLISTED"},
+			"bid_bt":              map[string]interface{}{"rangeEnd": map[string]interface{}{"rawValue": "60.00", "currency": "USD"}},
+			"jobSuccessScore_gte": float64(90),
+			"topRated_eq":         true,
+		}, reqs[0].Variables["filter"])
+		assert.Equal(t, map[string]interface{}{"field": "BID", "sortOrder": "DESC"}, reqs[0].Variables["sortAttribute"])
+	}
+}
+
+func TestGet(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("clientProposal", `{"clientProposal": null}`)
+
+	_, err := NewService(srv.Client()).Get(context.Background(), "p1")
+	assert.Equal(t, graphql.ErrNotFound, err)
+}
+
+func TestActions(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("shortlistProposal", `{"shortlistProposal": {"id": "p1", "status": "SHORTLISTED"}}`)
+	srv.Respond("archiveProposal", `{"archiveProposal": {"id": "p1", "status": "ARCHIVED"}}`)
+	srv.Respond("declineProposal", `{"declineProposal": {"id": "p1", "status": "DECLINED"}}`)
+	service := NewService(srv.Client())
+	ctx := context.Background()
+
+	p, err := service.Shortlist(ctx, Proposal{Id: "p1", Status: StatusActive})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusShortlisted, p.Status)
+	}
+	p, err = service.Archive(ctx, *p)
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusArchived, p.Status)
+	}
+	_, err = service.Decline(ctx, *p, "RUDE", "")
+	assert.EqualError(t, err, `upwork: applications: invalid reason "RUDE"`)
+	p, err = service.Decline(ctx, *p, ReasonHiredOther, "Thank you")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusDeclined, p.Status)
+	}
+	if reqs := srv.Requests("declineProposal"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"proposalId": "p1", "reason": "HIRED_SOMEONE_ELSE", "message": "Thank you"}, reqs[0].Variables["input"])
+	}
+
+	_, err = service.Shortlist(ctx, *p)
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	assert.EqualError(t, err, "upwork: applications: can not shortlist proposal p1 in status DECLINED")
+	assert.Len(t, srv.Requests("shortlistProposal"), 1)
+}
+
+func TestMessage(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	rooms := `{"roomList": {"totalCount": 0, "edges": []}}`
+	srv.Handle("roomList", func(req graphqltest.Request) (interface{}, error) {
+		return graphqltest.Raw(rooms), nil
+	})
+	srv.Respond("createRoomV2", `{"createRoomV2": {"id": "r1"}}`)
+	srv.Respond("sendMessageToRoom", `{"sendMessageToRoom": {"id": "s1", "message": "Hello"}}`)
+	service := NewService(srv.Client())
+	ctx := context.Background()
+	p := Proposal{Id: "p1", Status: StatusActive, Freelancer: Freelancer{UserId: "u1"}}
+
+	_, err := service.Message(ctx, p, " ")
+	assert.Error(t, err)
+	assert.Empty(t, srv.Requests("roomList"))
+
+	_, err = service.Message(ctx, Proposal{Id: "p1", Status: StatusActive}, "Hello")
+	assert.EqualError(t, err, "upwork: applications: proposal p1 has no freelancer user ID, the room can not be created")
+	assert.Empty(t, srv.Requests("createRoomV2"))
+
+	story, err := service.Message(ctx, p, "Hello")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "s1", story.Id)
+	}
+	if reqs := srv.Requests("createRoomV2"); assert.Len(t, reqs, 1) {
+		input := reqs[0].Variables["input"].(map[string]interface{})
+		assert.Equal(t, "p1", input["vendorProposalId"])
+	}
+
+	rooms = `{"roomList": {"totalCount": 1, "edges": [{"node": {"id": "r1"}}]}}`
+	_, err = service.Message(ctx, p, "Are you available?")
+	assert.NoError(t, err)
+	assert.Len(t, srv.Requests("createRoomV2"), 1)
+	if reqs := srv.Requests("sendMessageToRoom"); assert.Len(t, reqs, 2) {
+		assert.Equal(t, map[string]interface{}{"roomId": "r1", "message": "Are you available?"}, reqs[1].Variables["input"])
+	}
+}
@@ -0,0 +1,324 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package offers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Limits of an offer
+const (
+	MaxWeeklyLimit    = 168 // hours
+	MaxMessageLength  = 5000
+	MaxTitleLength    = 100
+	MaxMilestoneCount = 50
+)
+
+// Type of an offer
+type Kind string
+
+const (
+	KindHourly     Kind = "HOURLY"
+	KindFixedPrice Kind = "FIXED_PRICE"
+)
+
+// Status of an offer
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusAccepted  Status = "ACCEPTED"
+	StatusDeclined  Status = "DECLINED"
+	StatusWithdrawn Status = "WITHDRAWN"
+	StatusExpired   Status = "EXPIRED"
+)
+
+// Milestone of a fixed-price offer
+type Milestone struct {
+	Description string        `json:"description"`
+	Amount      graphql.Money `json:"amount"`
+	DueDate     graphql.Date  `json:"dueDate"` // optional
+}
+
+// Freelancer an offer is sent to
+type Freelancer struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Offer
+type Offer struct {
+	Id           string        `json:"id"`
+	Title        string        `json:"title"`
+	Kind         Kind          `json:"contractType"`
+	Status       Status        `json:"status"`
+	Freelancer   *Freelancer   `json:"freelancer"`
+	JobPostingId string        `json:"jobPostingId"`
+	Rate         graphql.Money `json:"hourlyRate"`  // hourly
+	WeeklyLimit  int           `json:"weeklyLimit"` // hourly, hours
+	Amount       graphql.Money `json:"amount"`      // fixed-price, total of the milestones
+	Milestones   []Milestone   `json:"milestones"`  // fixed-price
+	StartDate    graphql.Date  `json:"startDate"`
+	CreatedAt    time.Time     `json:"createdDateTime"`
+}
+
+// Builder of an offer, create it using Hourly or FixedPrice
+type Builder struct {
+	kind         Kind
+	title        string
+	freelancerId string
+	jobPostingId string
+	rate         graphql.Money
+	weeklyLimit  int
+	milestones   []Milestone
+	startDate    graphql.Date
+	message      string
+
+	now func() time.Time
+}
+
+// Start an hourly offer
+func Hourly(freelancerId string, title string, rate graphql.Money) *Builder {
+	return &Builder{kind: KindHourly, freelancerId: freelancerId, title: title, rate: rate, now: time.Now}
+}
+
+// Start a fixed-price offer, add the milestones using Milestone
+func FixedPrice(freelancerId string, title string) *Builder {
+	return &Builder{kind: KindFixedPrice, freelancerId: freelancerId, title: title, now: time.Now}
+}
+
+// Limit the hours per week of an hourly offer, no limit if 0
+func (b *Builder) WeeklyLimit(hours int) *Builder {
+	b.weeklyLimit = hours
+	return b
+}
+
+// Add a milestone to a fixed-price offer
+func (b *Builder) Milestone(description string, amount graphql.Money, due graphql.Date) *Builder {
+	b.milestones = append(b.milestones, Milestone{Description: description, Amount: amount, DueDate: due})
+	return b
+}
+
+// Set the start date of the contract
+func (b *Builder) StartDate(d graphql.Date) *Builder {
+	b.startDate = d
+	return b
+}
+
+// Attach a message to the freelancer
+func (b *Builder) Message(msg string) *Builder {
+	b.message = msg
+	return b
+}
+
+// Link the offer to a job posting
+func (b *Builder) JobPosting(id string) *Builder {
+	b.jobPostingId = id
+	return b
+}
+
+// Validate the offer and get the input of createOffer mutation, reports all invalid fields
+func (b *Builder) Build() (map[string]interface{}, error) {
+	var errs graphql.ValidationErrors
+	today := graphql.DateOf(b.now())
+
+	if b.freelancerId == "" {
+		errs.Add("freelancer", "is missing")
+	}
+	if n := utf8.RuneCountInString(strings.TrimSpace(b.title)); n == 0 {
+		errs.Add("title", "is missing")
+	} else if n > MaxTitleLength {
+		errs.Add("title", "is longer than %d characters", MaxTitleLength)
+	}
+	if utf8.RuneCountInString(b.message) > MaxMessageLength {
+		errs.Add("message", "is longer than %d characters", MaxMessageLength)
+	}
+	if !b.startDate.IsZero() && b.startDate.Before(today) {
+		errs.Add("startDate", "%s is in the past", b.startDate)
+	}
+
+	input := map[string]interface{}{
+		"contractType": b.kind,
+		"title":        strings.TrimSpace(b.title),
+		"freelancerId": b.freelancerId,
+	}
+
+	switch b.kind {
+	case KindHourly:
+		if err := b.rate.Validate(); err != nil {
+			errs.AddErr("rate", err)
+		}
+		if b.weeklyLimit < 0 || b.weeklyLimit > MaxWeeklyLimit {
+			errs.Add("weeklyLimit", "must be from 0 to %d hours", MaxWeeklyLimit)
+		}
+		if len(b.milestones) > 0 {
+			errs.Add("milestones", "are not allowed for an hourly offer")
+		}
+		input["hourlyRate"] = b.rate
+		if b.weeklyLimit > 0 {
+			input["weeklyLimit"] = b.weeklyLimit
+		}
+	case KindFixedPrice:
+		if len(b.milestones) == 0 {
+			errs.Add("milestones", "at least one milestone is required")
+		} else if len(b.milestones) > MaxMilestoneCount {
+			errs.Add("milestones", "at most %d milestones are allowed", MaxMilestoneCount)
+		}
+		for i, m := range b.milestones {
+			field := fmt.Sprintf("milestones[%d]", i)
+			if strings.TrimSpace(m.Description) == "" {
+				errs.Add(field+".description", "is missing")
+			}
+			if err := m.Amount.Validate(); err != nil {
+				errs.AddErr(field+".amount", err)
+			} else if !m.Amount.SameCurrency(b.milestones[0].Amount) {
+				errs.Add(field+".amount", "must be in %s", b.milestones[0].Amount.CurrencyCode())
+			}
+			if !m.DueDate.IsZero() && m.DueDate.Before(today) {
+				errs.Add(field+".dueDate", "%s is in the past", m.DueDate)
+			}
+			if !m.DueDate.IsZero() && !b.startDate.IsZero() && m.DueDate.Before(b.startDate) {
+				errs.Add(field+".dueDate", "%s is before the start date", m.DueDate)
+			}
+		}
+		input["milestones"] = b.milestones
+	default:
+		errs.Add("contractType", "invalid type %q", b.kind)
+	}
+
+	if b.jobPostingId != "" {
+		input["jobPostingId"] = b.jobPostingId
+	}
+	if !b.startDate.IsZero() {
+		input["startDate"] = b.startDate
+	}
+	if b.message != "" {
+		input["message"] = b.message
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// Filter of the offer list, empty fields are not applied
+type Filter struct {
+	Statuses     []Status
+	JobPostingId string
+}
+
+// Page of offers
+type Page struct {
+	Offers     []Offer
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Offer service of a client based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const offerFields = `id title contractType status freelancer { id name } jobPostingId
+	hourlyRate { rawValue currency } weeklyLimit amount { rawValue currency }
+	milestones { description amount { rawValue currency } dueDate } startDate createdDateTime`
+
+const clientOffersQuery = `query clientOffers($filter: OfferFilter, $pagination: Pagination) {
+  clientOffers(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + offerFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const offerQuery = `query offer($id: ID!) {
+  offer(id: $id) { ` + offerFields + ` }
+}`
+
+const createOfferMutation = `mutation createOffer($input: CreateOfferInput!) {
+  createOffer(input: $input) { ` + offerFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List the offers sent by the company
+func (s *Service) List(ctx context.Context, filter Filter, page graphql.Pagination) (*Page, error) {
+	var data struct {
+		ClientOffers graphql.Connection[Offer] `json:"clientOffers"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, clientOffersQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.ClientOffers
+	return &Page{Offers: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get a specific offer
+func (s *Service) Get(ctx context.Context, id string) (*Offer, error) {
+	var data struct {
+		Offer *Offer `json:"offer"`
+	}
+	if err := graphql.Do(ctx, s.client, offerQuery, map[string]interface{}{"id": id}, &data); err != nil {
+		return nil, err
+	}
+	if data.Offer == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.Offer, nil
+}
+
+// Validate and send an offer
+func (s *Service) Send(ctx context.Context, b *Builder) (*Offer, error) {
+	input, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		CreateOffer *Offer `json:"createOffer"`
+	}
+	if err := graphql.Do(ctx, s.client, createOfferMutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data.CreateOffer == nil {
+		return nil, fmt.Errorf("upwork: offers: createOffer returned no offer")
+	}
+	return data.CreateOffer, nil
+}
+
+// Get the OfferFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if len(f.Statuses) > 0 {
+		vars["status_any"] = f.Statuses
+	}
+	if f.JobPostingId != "" {
+		vars["jobPostingId_eq"] = f.JobPostingId
+	}
+	return vars
+}
@@ -0,0 +1,110 @@
+package offers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func fixedNow(b *Builder) *Builder {
+	b.now = func() time.Time { return time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return b
+}
+
+func date(month time.Month, day int) graphql.Date {
+	return graphql.Date{Year: 2023, Month: month, Day: day}
+}
+
+func TestBuildHourly(t *testing.T) {
+	input, err := fixedNow(Hourly("f1", "Go developer", graphql.USD(4500))).WeeklyLimit(20).StartDate(date(time.June, 5)).JobPosting("j1").Message("Welcome").Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"contractType": KindHourly,
+			"title":        "Go developer",
+			"freelancerId": "f1",
+			"hourlyRate":   graphql.USD(4500),
+			"weeklyLimit":  20,
+			"jobPostingId": "j1",
+			"startDate":    date(time.June, 5),
+			"message":      "Welcome",
+		}, input)
+	}
+
+	_, err = fixedNow(Hourly("", "", graphql.Money{})).WeeklyLimit(200).StartDate(date(time.May, 1)).Milestone("x", graphql.USD(1), graphql.Date{}).Build()
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		for _, field := range []string{"freelancer", "title", "rate", "weeklyLimit", "milestones", "startDate"} {
+			assert.True(t, errs.Has(field), field)
+		}
+	}
+}
+
+func TestBuildFixedPrice(t *testing.T) {
+	_, err := fixedNow(FixedPrice("f1", "Logo")).Build()
+	assert.EqualError(t, err, "upwork: invalid input: milestones: at least one milestone is required")
+
+	_, err = fixedNow(FixedPrice("f1", "Logo")).StartDate(date(time.June, 10)).
+		Milestone("Sketch", graphql.USD(10000), date(time.June, 5)).
+		Milestone("", graphql.Money{Cents: 100, Currency: "EUR"}, date(time.May, 1)).
+		Build()
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.True(t, errs.Has("milestones[0].dueDate"))
+		assert.True(t, errs.Has("milestones[1].description"))
+		assert.True(t, errs.Has("milestones[1].amount"))
+		assert.True(t, errs.Has("milestones[1].dueDate"))
+	}
+
+	input, err := fixedNow(FixedPrice("f1", "Logo")).Milestone("Sketch", graphql.USD(10000), date(time.June, 5)).Build()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Milestone{{Description: "Sketch", Amount: graphql.USD(10000), DueDate: date(time.June, 5)}}, input["milestones"])
+		assert.NotContains(t, input, "hourlyRate")
+	}
+}
+
+func TestSend(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("createOffer", `{"createOffer": {"id": "o1", "title": "Logo", "contractType": "FIXED_PRICE", "status": "PENDING", "amount": {"rawValue": "100", "currency": "USD"}, "milestones": [{"description": "Sketch", "amount": {"rawValue": "100", "currency": "USD"}, "dueDate": "2099-06-05"}]}}`)
+	service := NewService(srv.Client())
+
+	_, err := service.Send(context.Background(), FixedPrice("f1", "Logo"))
+	assert.Error(t, err)
+	assert.Empty(t, srv.Requests("createOffer"))
+
+	offer, err := service.Send(context.Background(), FixedPrice("f1", "Logo").Milestone("Sketch", graphql.USD(10000), graphql.Date{Year: 2099, Month: time.June, Day: 5}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusPending, offer.Status)
+		assert.Equal(t, graphql.USD(10000), offer.Amount)
+		assert.Len(t, offer.Milestones, 1)
+	}
+	if reqs := srv.Requests("createOffer"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"contractType": "FIXED_PRICE",
+			"title":        "Logo",
+			"freelancerId": "f1",
+			"milestones":   []interface{}{map[string]interface{}{"description": "Sketch", "amount": map[string]interface{}{"rawValue": "100.00", "currency": "USD"}, "dueDate": "2099-06-05"}},
+		}, reqs[0].Variables["input"])
+	}
+}
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("clientOffers", `{"clientOffers": {"totalCount": 2, "edges": [{"node": {"id": "o1", "contractType": "HOURLY", "status": "PENDING", "hourlyRate": {"rawValue": "45", "currency": "USD"}, "weeklyLimit": 10}}], "pageInfo": {"hasNextPage": true, "endCursor": "o1"}}}`)
+
+	page, err := NewService(srv.Client()).List(context.Background(), Filter{Statuses: []Status{StatusPending}, JobPostingId: "j1"}, graphql.Pagination{First: 1, After: "o0"})
+	if assert.NoError(t, err) && assert.Len(t, page.Offers, 1) {
+		assert.Equal(t, 10, page.Offers[0].WeeklyLimit)
+		assert.Equal(t, graphql.USD(4500), page.Offers[0].Rate)
+		assert.Equal(t, graphql.Pagination{First: 1, After: "o1"}, page.PageInfo.Next(1))
+	}
+	if reqs := srv.Requests("clientOffers"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"status_any": []interface{}{"PENDING"}, "jobPostingId_eq": "j1"}, reqs[0].Variables["filter"])
+		assert.Equal(t, map[string]interface{}{"first": float64(1), "after": "o0"}, reqs[0].Variables["pagination"])
+	}
+}
@@ -37,15 +37,15 @@ func New(c *api.ApiClient) *a {
 
 // Suspend Contract
 func (r a) SuspendContract(reference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/contracts.SuspendContract")
 }
 
 // Restart Contract
 func (r a) RestartContract(reference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/contracts.RestartContract")
 }
 
 // End Contract
 func (r a) EndContract(reference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/contracts.EndContract")
 }
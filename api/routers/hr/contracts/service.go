@@ -0,0 +1,307 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// ErrInvalidTransition is matched by the errors of the actions not allowed in the contract's status
+var ErrInvalidTransition = errors.New("upwork: contracts: invalid status transition")
+
+// Status of a contract
+type Status string
+
+const (
+	StatusActive Status = "ACTIVE"
+	StatusPaused Status = "PAUSED" // suspended
+	StatusEnded  Status = "ENDED"
+)
+
+// Action changing the status of a contract
+type Action string
+
+const (
+	ActionSuspend Action = "suspend"
+	ActionRestart Action = "restart"
+	ActionEnd     Action = "end"
+)
+
+// State machine of a contract, the status after an action allowed in a specific status
+var transitions = map[Status]map[Action]Status{
+	StatusActive: {ActionSuspend: StatusPaused, ActionEnd: StatusEnded},
+	StatusPaused: {ActionRestart: StatusActive, ActionEnd: StatusEnded},
+	StatusEnded:  {},
+}
+
+// Get the status of a contract after an action, fails if the action is not allowed
+func Transition(from Status, action Action) (Status, error) {
+	to, ok := transitions[from][action]
+	if !ok {
+		return from, &TransitionError{Status: from, Action: action}
+	}
+	return to, nil
+}
+
+// TransitionError is returned if an action is not allowed in the contract's status
+type TransitionError struct {
+	ContractId string
+	Status     Status
+	Action     Action
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("upwork: contracts: can not %s contract %s in status %s", e.Action, e.ContractId, e.Status)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Type of a contract
+type Kind string
+
+const (
+	KindHourly     Kind = "HOURLY"
+	KindFixedPrice Kind = "FIXED_PRICE"
+)
+
+// Reason of ending a contract
+type EndReason string
+
+const (
+	ReasonCompleted     EndReason = "JOB_COMPLETED_SUCCESSFULLY"
+	ReasonNotNeeded     EndReason = "NO_LONGER_NEEDED"
+	ReasonUnresponsive  EndReason = "FREELANCER_UNRESPONSIVE"
+	ReasonQuality       EndReason = "QUALITY_ISSUES"
+	ReasonMissedDueDate EndReason = "MISSED_DUE_DATE"
+	ReasonOther         EndReason = "OTHER"
+)
+
+var endReasons = map[EndReason]bool{
+	ReasonCompleted: true, ReasonNotNeeded: true, ReasonUnresponsive: true,
+	ReasonQuality: true, ReasonMissedDueDate: true, ReasonOther: true,
+}
+
+// Freelancer of a contract
+type Freelancer struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Terms of a contract
+type Terms struct {
+	HourlyRate        graphql.Money `json:"hourlyRate"`       // hourly contracts
+	WeeklyLimit       int           `json:"weeklyHoursLimit"` // hours, no limit if 0
+	ManualTimeAllowed bool          `json:"manualTimeAllowed"`
+	FixedAmount       graphql.Money `json:"fixedAmount"` // fixed-price contracts
+}
+
+// Contract
+type Contract struct {
+	Id         string       `json:"id"`
+	Title      string       `json:"title"`
+	Kind       Kind         `json:"kind"`
+	Status     Status       `json:"status"`
+	Freelancer *Freelancer  `json:"freelancer"`
+	StartDate  graphql.Date `json:"startDate"`
+	EndDate    graphql.Date `json:"endDate"`
+	Terms      Terms        `json:"terms"`
+}
+
+// Check if an action is allowed in the contract's status
+func (c Contract) Can(action Action) error {
+	if _, err := Transition(c.Status, action); err != nil {
+		return &TransitionError{ContractId: c.Id, Status: c.Status, Action: action}
+	}
+	return nil
+}
+
+// Filter of the contract list, empty fields are not applied
+type Filter struct {
+	Statuses      []Status
+	StartedAfter  graphql.Date
+	StartedBefore graphql.Date
+	FreelancerId  string
+}
+
+// Page of contracts
+type Page struct {
+	Contracts  []Contract
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Feedback to the freelancer given when a contract ends
+type Feedback struct {
+	Score   float64 // from 1 to 5
+	Comment string
+}
+
+// Ending of a contract
+type Ending struct {
+	Reason   EndReason
+	Feedback *Feedback // optional
+	Message  string    // message to the freelancer
+}
+
+// Contract service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const contractFields = `id title kind status freelancer { id name } startDate endDate
+	terms { hourlyRate { rawValue currency } weeklyHoursLimit manualTimeAllowed fixedAmount { rawValue currency } }`
+
+const contractListQuery = `query contractList($filter: ContractFilter, $pagination: Pagination) {
+  contractList(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + contractFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const contractQuery = `query contract($id: ID!) {
+  contract(id: $id) { ` + contractFields + ` }
+}`
+
+const pauseContractMutation = `mutation pauseContract($input: PauseContractInput!) {
+  pauseContract(input: $input) { ` + contractFields + ` }
+}`
+
+const restartContractMutation = `mutation restartContract($input: RestartContractInput!) {
+  restartContract(input: $input) { ` + contractFields + ` }
+}`
+
+const endContractMutation = `mutation endContract($input: EndContractInput!) {
+  endContract(input: $input) { ` + contractFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List contracts
+func (s *Service) List(ctx context.Context, filter Filter, page graphql.Pagination) (*Page, error) {
+	var data struct {
+		ContractList graphql.Connection[Contract] `json:"contractList"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, contractListQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.ContractList
+	return &Page{Contracts: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get the details of a specific contract
+func (s *Service) Get(ctx context.Context, contractId string) (*Contract, error) {
+	var data struct {
+		Contract *Contract `json:"contract"`
+	}
+	if err := graphql.Do(ctx, s.client, contractQuery, map[string]interface{}{"id": contractId}, &data); err != nil {
+		return nil, err
+	}
+	if data.Contract == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.Contract, nil
+}
+
+// Suspend an active contract
+func (s *Service) Suspend(ctx context.Context, c Contract, message string) (*Contract, error) {
+	if err := c.Can(ActionSuspend); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{"contractId": c.Id}
+	if message != "" {
+		input["message"] = message
+	}
+	return s.mutate(ctx, pauseContractMutation, "pauseContract", input)
+}
+
+// Restart a suspended contract
+func (s *Service) Restart(ctx context.Context, c Contract, message string) (*Contract, error) {
+	if err := c.Can(ActionRestart); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{"contractId": c.Id}
+	if message != "" {
+		input["message"] = message
+	}
+	return s.mutate(ctx, restartContractMutation, "restartContract", input)
+}
+
+// End an active or suspended contract
+func (s *Service) End(ctx context.Context, c Contract, e Ending) (*Contract, error) {
+	if err := c.Can(ActionEnd); err != nil {
+		return nil, err
+	}
+	if !endReasons[e.Reason] {
+		return nil, fmt.Errorf("upwork: contracts: invalid reason %q", e.Reason)
+	}
+
+	input := map[string]interface{}{"contractId": c.Id, "reason": e.Reason}
+	if e.Feedback != nil {
+		if !(e.Feedback.Score >= 1 && e.Feedback.Score <= 5) { // rejects NaN too
+			return nil, fmt.Errorf("upwork: contracts: feedback score must be from 1 to 5, got %v", e.Feedback.Score)
+		}
+		feedback := map[string]interface{}{"score": e.Feedback.Score}
+		if e.Feedback.Comment != "" {
+			feedback["comment"] = e.Feedback.Comment
+		}
+		input["feedback"] = feedback
+	}
+	if e.Message != "" {
+		input["message"] = e.Message
+	}
+	return s.mutate(ctx, endContractMutation, "endContract", input)
+}
+
+// Run a mutation returning a contract
+func (s *Service) mutate(ctx context.Context, mutation string, field string, input map[string]interface{}) (*Contract, error) {
+	var data map[string]*Contract
+	if err := graphql.Do(ctx, s.client, mutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data[field] == nil {
+		return nil, fmt.Errorf("upwork: contracts: %s returned no contract", field)
+	}
+	return data[field], nil
+}
+
+// Get the ContractFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if len(f.Statuses) > 0 {
+		vars["status_any"] = f.Statuses
+	}
+	if !f.StartedAfter.IsZero() || !f.StartedBefore.IsZero() {
+		vars["startDate_bt"] = map[string]interface{}{"rangeStart": f.StartedAfter, "rangeEnd": f.StartedBefore}
+	}
+	if f.FreelancerId != "" {
+		vars["freelancerId_eq"] = f.FreelancerId
+	}
+	return vars
+}
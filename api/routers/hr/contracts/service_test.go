@@ -0,0 +1,130 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+const contractJson = `{
+	"id": "c1",
+	"title": "Backend development",
+	"kind": "HOURLY",
+	"status": "ACTIVE",
+	"freelancer": {"id": "f1", "name": "Jane"},
+	"startDate": "2023-01-15",
+	"endDate": null,
+	"terms": {"hourlyRate": {"rawValue": "45.00", "currency": "USD"}, "weeklyHoursLimit": 20, "manualTimeAllowed": true, "fixedAmount": null}
+}`
+
+func TestTransition(t *testing.T) {
+	for _, tc := range []struct {
+		from   Status
+		action Action
+		to     Status
+		ok     bool
+	}{
+		{StatusActive, ActionSuspend, StatusPaused, true},
+		{StatusActive, ActionEnd, StatusEnded, true},
+		{StatusActive, ActionRestart, StatusActive, false},
+		{StatusPaused, ActionRestart, StatusActive, true},
+		{StatusPaused, ActionEnd, StatusEnded, true},
+		{StatusPaused, ActionSuspend, StatusPaused, false},
+		{StatusEnded, ActionRestart, StatusEnded, false},
+		{StatusEnded, ActionEnd, StatusEnded, false},
+	} {
+		to, err := Transition(tc.from, tc.action)
+		assert.Equal(t, tc.to, to, "%s %s", tc.action, tc.from)
+		assert.Equal(t, tc.ok, err == nil, "%s %s", tc.action, tc.from)
+	}
+}
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("contractList", `{"contractList": {"totalCount": 1, "edges": [{"node": `+contractJson+`}], "pageInfo": {"hasNextPage": false, "endCursor": "x"}}}`)
+
+	filter := Filter{Statuses: []Status{StatusActive, StatusPaused}, StartedAfter: graphql.Date{Year: 2023, Month: time.January, Day: 1}, FreelancerId: "f1"}
+	page, err := NewService(srv.Client()).List(context.Background(), filter, graphql.Pagination{First: 10})
+	if assert.NoError(t, err) && assert.Len(t, page.Contracts, 1) {
+		c := page.Contracts[0]
+		assert.Equal(t, KindHourly, c.Kind)
+		assert.Equal(t, graphql.USD(4500), c.Terms.HourlyRate)
+		assert.Equal(t, 20, c.Terms.WeeklyLimit)
+		assert.True(t, c.EndDate.IsZero())
+		assert.Equal(t, "Jane", c.Freelancer.Name)
+	}
+
+	if reqs := srv.Requests("contractList"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"status_any":      []interface{}{"ACTIVE", "PAUSED"},
+			"startDate_bt":    map[string]interface{}{"rangeStart": "2023-01-01", "rangeEnd": nil},
+			"freelancerId_eq": "f1",
+		}, reqs[0].Variables["filter"])
+	}
+}
+
+func TestGet(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("contract", `{"contract": null}`)
+
+	_, err := NewService(srv.Client()).Get(context.Background(), "c2")
+	assert.Equal(t, graphql.ErrNotFound, err)
+}
+
+func TestStatusChanges(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("pauseContract", `{"pauseContract": {"id": "c1", "status": "PAUSED"}}`)
+	srv.Respond("restartContract", `{"restartContract": {"id": "c1", "status": "ACTIVE"}}`)
+	srv.Respond("endContract", `{"endContract": {"id": "c1", "status": "ENDED"}}`)
+	service := NewService(srv.Client())
+	ctx := context.Background()
+
+	active := Contract{Id: "c1", Status: StatusActive}
+	_, err := service.Restart(ctx, active, "")
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	assert.EqualError(t, err, "upwork: contracts: can not restart contract c1 in status ACTIVE")
+
+	paused, err := service.Suspend(ctx, active, "on hold")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusPaused, paused.Status)
+		_, err = service.Suspend(ctx, *paused, "")
+		assert.True(t, errors.Is(err, ErrInvalidTransition))
+
+		restarted, err := service.Restart(ctx, *paused, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, StatusActive, restarted.Status)
+		}
+	}
+
+	_, err = service.End(ctx, active, Ending{Reason: "BORED"})
+	assert.Error(t, err)
+	for _, score := range []float64{0, 6, math.NaN()} {
+		_, err = service.End(ctx, active, Ending{Reason: ReasonCompleted, Feedback: &Feedback{Score: score}})
+		assert.Error(t, err, score)
+	}
+	_, err = service.End(ctx, Contract{Id: "c1", Status: StatusEnded}, Ending{Reason: ReasonCompleted})
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	assert.Empty(t, srv.Requests("endContract"))
+
+	ended, err := service.End(ctx, active, Ending{Reason: ReasonCompleted, Feedback: &Feedback{Score: 4.5, Comment: "Great work"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusEnded, ended.Status)
+	}
+	if reqs := srv.Requests("endContract"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"contractId": "c1",
+			"reason":     "JOB_COMPLETED_SUCCESSFULLY",
+			"feedback":   map[string]interface{}{"score": 4.5, "comment": "Great work"},
+		}, reqs[0].Variables["input"])
+	}
+	if reqs := srv.Requests("pauseContract"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"contractId": "c1", "message": "on hold"}, reqs[0].Variables["input"])
+	}
+}
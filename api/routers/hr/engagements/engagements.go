@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get list of engagements
 func (r a) GetList(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/engagements.GetList")
 }
 
 // Get specific engagement
 func (r a) GetSpecific(reference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/engagements.GetSpecific")
 }
@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get list of applications
 func (r a) GetList(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/freelancers/applications.GetList")
 }
 
 // Get specific application
 func (r a) GetSpecific(reference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/freelancers/applications.GetSpecific")
 }
@@ -0,0 +1,175 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package applications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Status of a proposal
+type Status string
+
+const (
+	StatusSubmitted    Status = "SUBMITTED"
+	StatusInterviewing Status = "INTERVIEWING"
+	StatusWithdrawn    Status = "WITHDRAWN"
+	StatusDeclined     Status = "DECLINED"
+	StatusHired        Status = "HIRED"
+)
+
+// Reason of withdrawing a proposal
+type WithdrawReason string
+
+const (
+	WithdrawAppliedByMistake   WithdrawReason = "APPLIED_BY_MISTAKE"
+	WithdrawRateTooLow         WithdrawReason = "RATE_TOO_LOW"
+	WithdrawNoLongerAvailable  WithdrawReason = "NO_LONGER_AVAILABLE"
+	WithdrawSchedulingConflict WithdrawReason = "SCHEDULING_CONFLICT"
+	WithdrawOther              WithdrawReason = "OTHER"
+)
+
+var withdrawReasons = map[WithdrawReason]bool{
+	WithdrawAppliedByMistake: true, WithdrawRateTooLow: true, WithdrawNoLongerAvailable: true,
+	WithdrawSchedulingConflict: true, WithdrawOther: true,
+}
+
+// Job posting a proposal was submitted to
+type JobPosting struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Proposal submitted by a freelancer or an agency
+type Proposal struct {
+	Id           string        `json:"id"`
+	JobPosting   JobPosting    `json:"jobPosting"`
+	Status       Status        `json:"status"`
+	FreelancerId string        `json:"freelancerId"`
+	CoverLetter  string        `json:"coverLetter"`
+	Bid          graphql.Money `json:"bid"` // hourly rate or fixed amount
+	CreatedAt    time.Time     `json:"createdDateTime"`
+}
+
+// Filter of the proposal list, empty fields are not applied
+type Filter struct {
+	Statuses     []Status
+	FreelancerId string // proposals of a specific member of an agency
+}
+
+// Page of proposals
+type Page struct {
+	Proposals  []Proposal
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Proposal service of a freelancer or an agency based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const proposalFields = `id jobPosting { id title } status freelancerId coverLetter bid { rawValue currency } createdDateTime`
+
+const vendorProposalsQuery = `query vendorProposals($filter: VendorProposalFilter, $pagination: Pagination) {
+  vendorProposals(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + proposalFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const vendorProposalQuery = `query vendorProposal($id: ID!) {
+  vendorProposal(id: $id) { ` + proposalFields + ` }
+}`
+
+const withdrawProposalMutation = `mutation withdrawProposal($input: WithdrawProposalInput!) {
+  withdrawProposal(input: $input) { ` + proposalFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List the submitted proposals
+func (s *Service) List(ctx context.Context, filter Filter, page graphql.Pagination) (*Page, error) {
+	var data struct {
+		VendorProposals graphql.Connection[Proposal] `json:"vendorProposals"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, vendorProposalsQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.VendorProposals
+	return &Page{Proposals: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get a specific proposal
+func (s *Service) Get(ctx context.Context, id string) (*Proposal, error) {
+	var data struct {
+		VendorProposal *Proposal `json:"vendorProposal"`
+	}
+	if err := graphql.Do(ctx, s.client, vendorProposalQuery, map[string]interface{}{"id": id}, &data); err != nil {
+		return nil, err
+	}
+	if data.VendorProposal == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.VendorProposal, nil
+}
+
+// Withdraw a proposal, which is still under consideration
+func (s *Service) Withdraw(ctx context.Context, p Proposal, reason WithdrawReason, message string) (*Proposal, error) {
+	if p.Status != StatusSubmitted && p.Status != StatusInterviewing {
+		return nil, fmt.Errorf("upwork: applications: proposal %s is %s, it can not be withdrawn", p.Id, p.Status)
+	}
+	if !withdrawReasons[reason] {
+		return nil, fmt.Errorf("upwork: applications: invalid reason %q", reason)
+	}
+
+	input := map[string]interface{}{"proposalId": p.Id, "reason": reason}
+	if message != "" {
+		input["message"] = message
+	}
+
+	var data struct {
+		WithdrawProposal *Proposal `json:"withdrawProposal"`
+	}
+	if err := graphql.Do(ctx, s.client, withdrawProposalMutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data.WithdrawProposal == nil {
+		return nil, fmt.Errorf("upwork: applications: withdrawProposal returned no proposal")
+	}
+	return data.WithdrawProposal, nil
+}
+
+// Get the VendorProposalFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if len(f.Statuses) > 0 {
+		vars["status_any"] = f.Statuses
+	}
+	if f.FreelancerId != "" {
+		vars["freelancerId_eq"] = f.FreelancerId
+	}
+	return vars
+}
@@ -0,0 +1,59 @@
+package applications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("vendorProposals", `{"vendorProposals": {"totalCount": 3, "edges": [{"node": {"id": "p1", "jobPosting": {"id": "j1", "title": "Go developer"}, "status": "SUBMITTED", "bid": {"rawValue": "50", "currency": "USD"}}}], "pageInfo": {"hasNextPage": true, "endCursor": "p1"}}}`)
+
+	page, err := NewService(srv.Client()).List(context.Background(), Filter{Statuses: []Status{StatusSubmitted, StatusInterviewing}}, graphql.Pagination{First: 1})
+	if assert.NoError(t, err) && assert.Len(t, page.Proposals, 1) {
+		assert.Equal(t, 3, page.TotalCount)
+		assert.Equal(t, "Go developer", page.Proposals[0].JobPosting.Title)
+		assert.Equal(t, graphql.USD(5000), page.Proposals[0].Bid)
+		assert.Equal(t, graphql.Pagination{First: 1, After: "p1"}, page.PageInfo.Next(1))
+	}
+	if reqs := srv.Requests("vendorProposals"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"status_any": []interface{}{"SUBMITTED", "INTERVIEWING"}}, reqs[0].Variables["filter"])
+	}
+}
+
+func TestGet(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("vendorProposal", `{"vendorProposal": {"id": "p1", "coverLetter": "Hello", "status": "HIRED"}}`)
+
+	proposal, err := NewService(srv.Client()).Get(context.Background(), "p1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Hello", proposal.CoverLetter)
+		assert.Equal(t, StatusHired, proposal.Status)
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("withdrawProposal", `{"withdrawProposal": {"id": "p1", "status": "WITHDRAWN"}}`)
+	service := NewService(srv.Client())
+	ctx := context.Background()
+
+	_, err := service.Withdraw(ctx, Proposal{Id: "p1", Status: StatusHired}, WithdrawOther, "")
+	assert.EqualError(t, err, "upwork: applications: proposal p1 is HIRED, it can not be withdrawn")
+	_, err = service.Withdraw(ctx, Proposal{Id: "p1", Status: StatusSubmitted}, "BORED", "")
+	assert.EqualError(t, err, `upwork: applications: invalid reason "BORED"`)
+	assert.Empty(t, srv.Requests("withdrawProposal"))
+
+	proposal, err := service.Withdraw(ctx, Proposal{Id: "p1", Status: StatusInterviewing}, WithdrawSchedulingConflict, "Sorry")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusWithdrawn, proposal.Status)
+	}
+	if reqs := srv.Requests("withdrawProposal"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"proposalId": "p1", "reason": "SCHEDULING_CONFLICT", "message": "Sorry"}, reqs[0].Variables["input"])
+	}
+}
@@ -37,15 +37,15 @@ func New(c *api.ApiClient) *a {
 
 // Get list of offers
 func (r a) GetList(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/freelancers/offers.GetList")
 }
 
 // Get specific offer
 func (r a) GetSpecific(reference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/freelancers/offers.GetSpecific")
 }
 
 // Run a specific action
 func (r a) MakeOffer(reference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/freelancers/offers.MakeOffer")
 }
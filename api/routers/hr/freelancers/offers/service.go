@@ -0,0 +1,184 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package offers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Type of an offer
+type Kind string
+
+const (
+	KindHourly     Kind = "HOURLY"
+	KindFixedPrice Kind = "FIXED_PRICE"
+)
+
+// Status of an offer
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusAccepted  Status = "ACCEPTED"
+	StatusDeclined  Status = "DECLINED"
+	StatusWithdrawn Status = "WITHDRAWN"
+	StatusExpired   Status = "EXPIRED"
+)
+
+// Client who sent an offer
+type Client struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Offer received by a freelancer or an agency
+type Offer struct {
+	Id           string        `json:"id"`
+	Title        string        `json:"title"`
+	Kind         Kind          `json:"contractType"`
+	Status       Status        `json:"status"`
+	Client       *Client       `json:"client"`
+	FreelancerId string        `json:"freelancerId"` // agency member the offer is for
+	Rate         graphql.Money `json:"hourlyRate"`   // hourly
+	WeeklyLimit  int           `json:"weeklyLimit"`  // hourly, hours
+	Amount       graphql.Money `json:"amount"`       // fixed-price
+	StartDate    graphql.Date  `json:"startDate"`
+	Message      string        `json:"message"`
+	ExpiresAt    time.Time     `json:"expirationDateTime"`
+	CreatedAt    time.Time     `json:"createdDateTime"`
+}
+
+// Filter of the offer list, empty fields are not applied
+type Filter struct {
+	Statuses     []Status
+	FreelancerId string // offers for a specific member of an agency
+}
+
+// Page of offers
+type Page struct {
+	Offers     []Offer
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Offer service of a freelancer or an agency based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const offerFields = `id title contractType status client { id name } freelancerId
+	hourlyRate { rawValue currency } weeklyLimit amount { rawValue currency }
+	startDate message expirationDateTime createdDateTime`
+
+const vendorOffersQuery = `query vendorOffers($filter: OfferFilter, $pagination: Pagination) {
+  vendorOffers(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + offerFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const offerQuery = `query offer($id: ID!) {
+  offer(id: $id) { ` + offerFields + ` }
+}`
+
+const acceptOfferMutation = `mutation acceptOffer($input: AcceptOfferInput!) {
+  acceptOffer(input: $input) { ` + offerFields + ` }
+}`
+
+const declineOfferMutation = `mutation declineOffer($input: DeclineOfferInput!) {
+  declineOffer(input: $input) { ` + offerFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List the received offers
+func (s *Service) List(ctx context.Context, filter Filter, page graphql.Pagination) (*Page, error) {
+	var data struct {
+		VendorOffers graphql.Connection[Offer] `json:"vendorOffers"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, vendorOffersQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.VendorOffers
+	return &Page{Offers: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get a specific offer
+func (s *Service) Get(ctx context.Context, id string) (*Offer, error) {
+	var data struct {
+		Offer *Offer `json:"offer"`
+	}
+	if err := graphql.Do(ctx, s.client, offerQuery, map[string]interface{}{"id": id}, &data); err != nil {
+		return nil, err
+	}
+	if data.Offer == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.Offer, nil
+}
+
+// Accept a pending offer, the message to the client is optional
+func (s *Service) Accept(ctx context.Context, o Offer, message string) (*Offer, error) {
+	return s.respond(ctx, o, acceptOfferMutation, "acceptOffer", message)
+}
+
+// Decline a pending offer, the message to the client is optional
+func (s *Service) Decline(ctx context.Context, o Offer, message string) (*Offer, error) {
+	return s.respond(ctx, o, declineOfferMutation, "declineOffer", message)
+}
+
+// Accept or decline an offer
+func (s *Service) respond(ctx context.Context, o Offer, mutation string, field string, message string) (*Offer, error) {
+	if o.Status != StatusPending {
+		return nil, fmt.Errorf("upwork: offers: offer %s is %s, only a pending offer can be accepted or declined", o.Id, o.Status)
+	}
+
+	input := map[string]interface{}{"offerId": o.Id}
+	if message != "" {
+		input["message"] = message
+	}
+
+	var data map[string]*Offer
+	if err := graphql.Do(ctx, s.client, mutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data[field] == nil {
+		return nil, fmt.Errorf("upwork: offers: %s returned no offer", field)
+	}
+	return data[field], nil
+}
+
+// Get the OfferFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if len(f.Statuses) > 0 {
+		vars["status_any"] = f.Statuses
+	}
+	if f.FreelancerId != "" {
+		vars["freelancerId_eq"] = f.FreelancerId
+	}
+	return vars
+}
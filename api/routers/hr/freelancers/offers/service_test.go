@@ -0,0 +1,62 @@
+package offers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("vendorOffers", `{"vendorOffers": {"totalCount": 1, "edges": [{"node": {"id": "o1", "contractType": "HOURLY", "status": "PENDING", "client": {"id": "c1", "name": "Acme"}, "hourlyRate": {"rawValue": "45", "currency": "USD"}, "startDate": "2023-06-05"}}], "pageInfo": {"hasNextPage": false}}}`)
+
+	page, err := NewService(srv.Client()).List(context.Background(), Filter{Statuses: []Status{StatusPending}, FreelancerId: "f1"}, graphql.Pagination{})
+	if assert.NoError(t, err) && assert.Len(t, page.Offers, 1) {
+		assert.Equal(t, "Acme", page.Offers[0].Client.Name)
+		assert.Equal(t, graphql.USD(4500), page.Offers[0].Rate)
+		assert.Equal(t, "2023-06-05", page.Offers[0].StartDate.String())
+	}
+	if reqs := srv.Requests("vendorOffers"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"status_any": []interface{}{"PENDING"}, "freelancerId_eq": "f1"}, reqs[0].Variables["filter"])
+	}
+}
+
+func TestGet(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("offer", `{"offer": null}`)
+
+	_, err := NewService(srv.Client()).Get(context.Background(), "o1")
+	assert.Equal(t, graphql.ErrNotFound, err)
+}
+
+func TestRespond(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("acceptOffer", `{"acceptOffer": {"id": "o1", "status": "ACCEPTED"}}`)
+	srv.Respond("declineOffer", `{"declineOffer": {"id": "o2", "status": "DECLINED"}}`)
+	service := NewService(srv.Client())
+	ctx := context.Background()
+
+	offer, err := service.Accept(ctx, Offer{Id: "o1", Status: StatusPending}, "Thanks!")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusAccepted, offer.Status)
+	}
+	if reqs := srv.Requests("acceptOffer"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"offerId": "o1", "message": "Thanks!"}, reqs[0].Variables["input"])
+	}
+
+	offer, err = service.Decline(ctx, Offer{Id: "o2", Status: StatusPending}, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusDeclined, offer.Status)
+	}
+	if reqs := srv.Requests("declineOffer"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"offerId": "o2"}, reqs[0].Variables["input"])
+	}
+
+	_, err = service.Accept(ctx, Offer{Id: "o3", Status: StatusExpired}, "")
+	assert.EqualError(t, err, "upwork: offers: offer o3 is EXPIRED, only a pending offer can be accepted or declined")
+	assert.Len(t, srv.Requests("acceptOffer"), 1)
+}
@@ -0,0 +1,291 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package interviews
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/hr/clients/applications"
+)
+
+// Maximum length of an invitation message
+const MaxMessageLength = 5000
+
+// Status of an invitation
+type Status string
+
+const (
+	StatusPending  Status = "PENDING"
+	StatusAccepted Status = "ACCEPTED"
+	StatusDeclined Status = "DECLINED"
+	StatusExpired  Status = "EXPIRED"
+)
+
+// Invitation of a freelancer to interview for a job posting
+type Invitation struct {
+	JobPostingId string
+	FreelancerId string // profile
+	Message      string
+}
+
+// Sent invitation
+type Interview struct {
+	Id           string    `json:"id"`
+	JobPostingId string    `json:"jobPostingId"`
+	FreelancerId string    `json:"freelancerId"`
+	Status       Status    `json:"status"`
+	Message      string    `json:"message"`
+	CreatedAt    time.Time `json:"createdDateTime"`
+}
+
+// Status of a freelancer invited in bulk
+type InviteStatus string
+
+const (
+	InviteOk      InviteStatus = "ok"
+	InviteFailed  InviteStatus = "error"
+	InviteSkipped InviteStatus = "skipped"
+)
+
+// Reasons of skipping a freelancer
+const (
+	SkippedDuplicate      = "duplicate"
+	SkippedAlreadyInvited = "already invited"
+	SkippedAlreadyApplied = "already applied"
+)
+
+// Invitation of many freelancers to interview for a job posting
+type BulkInvitation struct {
+	JobPostingId  string
+	FreelancerIds []string // profiles
+	Message       string
+}
+
+// Result of a freelancer invited in bulk
+type InviteResult struct {
+	FreelancerId string
+	Status       InviteStatus
+	Interview    *Interview // the sent invitation if ok
+	Err          error      // the failure if error
+	Reason       string     // the reason if skipped
+}
+
+// Results of a bulk invitation in the order of the freelancers
+type InviteResults []InviteResult
+
+// Get the freelancers invited by the bulk invitation
+func (r InviteResults) Invited() []string {
+	var ids []string
+	for _, res := range r {
+		if res.Status == InviteOk {
+			ids = append(ids, res.FreelancerId)
+		}
+	}
+	return ids
+}
+
+// Get the freelancers not invited because of an error
+func (r InviteResults) Failed() []string {
+	var ids []string
+	for _, res := range r {
+		if res.Status == InviteFailed {
+			ids = append(ids, res.FreelancerId)
+		}
+	}
+	return ids
+}
+
+// InviteError is returned if some of the freelancers were not invited
+type InviteError struct {
+	Failed int
+	Total  int
+	Err    error // first failure
+}
+
+func (e *InviteError) Error() string {
+	return fmt.Sprintf("upwork: interviews: invitation failed for %d of %d freelancers: %v", e.Failed, e.Total, e.Err)
+}
+
+func (e *InviteError) Unwrap() error {
+	return e.Err
+}
+
+// Interview invitation service based on GraphQL API
+type Service struct {
+	client    *api.ApiClient
+	proposals *applications.Service
+}
+
+const interviewFields = `id jobPostingId freelancerId status message createdDateTime`
+
+const inviteMutation = `mutation inviteToInterview($input: InviteToInterviewInput!) {
+  inviteToInterview(input: $input) { ` + interviewFields + ` }
+}`
+
+const invitationsQuery = `query interviewInvitations($jobPostingId: ID!, $pagination: Pagination) {
+  interviewInvitations(jobPostingId: $jobPostingId, pagination: $pagination) {
+    totalCount
+    edges { node { ` + interviewFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c, applications.NewService(c)}
+}
+
+// Invite a freelancer to interview for a job posting
+func (s *Service) Invite(ctx context.Context, inv Invitation) (*Interview, error) {
+	if err := inv.validate(); err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		InviteToInterview *Interview `json:"inviteToInterview"`
+	}
+	input := map[string]interface{}{"jobPostingId": inv.JobPostingId, "freelancerId": inv.FreelancerId, "message": inv.Message}
+	if err := graphql.Do(ctx, s.client, inviteMutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data.InviteToInterview == nil {
+		return nil, fmt.Errorf("upwork: interviews: inviteToInterview returned no invitation")
+	}
+	return data.InviteToInterview, nil
+}
+
+// Get all the invitations sent for a job posting
+func (s *Service) Invitations(ctx context.Context, jobPostingId string) ([]Interview, error) {
+	interviews := []Interview{}
+	page := graphql.Pagination{}.OrDefault()
+	for {
+		var data struct {
+			InterviewInvitations graphql.Connection[Interview] `json:"interviewInvitations"`
+		}
+		vars := map[string]interface{}{"jobPostingId": jobPostingId, "pagination": page}
+		if err := graphql.Do(ctx, s.client, invitationsQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		c := data.InterviewInvitations
+		interviews = append(interviews, c.Nodes()...)
+		if !c.PageInfo.HasNextPage {
+			return interviews, nil
+		}
+		page = c.PageInfo.Next(page.First)
+	}
+}
+
+// Invite many freelancers to interview for a job posting. The freelancers already invited,
+// who already applied or listed twice are skipped. The results are returned even on failure.
+func (s *Service) InviteAll(ctx context.Context, b BulkInvitation) (InviteResults, error) {
+	var errs graphql.ValidationErrors
+	validate(&errs, b.JobPostingId, b.Message)
+	if len(b.FreelancerIds) == 0 {
+		errs.Add("freelancers", "are missing")
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	invited, err := s.Invitations(ctx, b.JobPostingId)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := s.applicants(ctx, b.JobPostingId)
+	if err != nil {
+		return nil, err
+	}
+	skipped := make(map[string]string)
+	for id := range applied {
+		skipped[id] = SkippedAlreadyApplied
+	}
+	for _, i := range invited {
+		skipped[i.FreelancerId] = SkippedAlreadyInvited
+	}
+
+	results := make(InviteResults, len(b.FreelancerIds))
+	var failed int
+	var first error
+	for i, id := range b.FreelancerIds {
+		results[i] = InviteResult{FreelancerId: id, Status: InviteSkipped}
+		if reason, ok := skipped[id]; ok {
+			results[i].Reason = reason
+			continue
+		}
+		skipped[id] = SkippedDuplicate
+
+		interview, err := s.Invite(ctx, Invitation{JobPostingId: b.JobPostingId, FreelancerId: id, Message: b.Message})
+		if err != nil {
+			results[i] = InviteResult{FreelancerId: id, Status: InviteFailed, Err: err}
+			if failed++; first == nil {
+				first = err
+			}
+			continue
+		}
+		results[i] = InviteResult{FreelancerId: id, Status: InviteOk, Interview: interview}
+	}
+
+	if failed > 0 {
+		return results, &InviteError{Failed: failed, Total: len(b.FreelancerIds), Err: first}
+	}
+	return results, nil
+}
+
+// Get the freelancers who applied to a job posting
+func (s *Service) applicants(ctx context.Context, jobPostingId string) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	page := graphql.Pagination{}.OrDefault()
+	for {
+		proposals, err := s.proposals.List(ctx, jobPostingId, applications.Filter{}, applications.Sort{}, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range proposals.Proposals {
+			ids[p.Freelancer.Id] = true
+		}
+		if !proposals.PageInfo.HasNextPage {
+			return ids, nil
+		}
+		page = proposals.PageInfo.Next(page.First)
+	}
+}
+
+// Validate an invitation
+func (inv Invitation) validate() error {
+	var errs graphql.ValidationErrors
+	validate(&errs, inv.JobPostingId, inv.Message)
+	if inv.FreelancerId == "" {
+		errs.Add("freelancer", "is missing")
+	}
+	return errs.Err()
+}
+
+// Validate the fields shared by single and bulk invitations
+func validate(errs *graphql.ValidationErrors, jobPostingId string, message string) {
+	if jobPostingId == "" {
+		errs.Add("jobPosting", "is missing")
+	}
+	if strings.TrimSpace(message) == "" {
+		errs.Add("message", "is missing")
+	} else if utf8.RuneCountInString(message) > MaxMessageLength {
+		errs.Add("message", "is longer than %d characters", MaxMessageLength)
+	}
+}
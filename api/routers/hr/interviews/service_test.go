@@ -0,0 +1,94 @@
+package interviews
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func TestInvite(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("inviteToInterview", `{"inviteToInterview": {"id": "i1", "jobPostingId": "j1", "freelancerId": "~01", "status": "PENDING"}}`)
+	service := NewService(srv.Client())
+
+	_, err := service.Invite(context.Background(), Invitation{Message: " "})
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.True(t, errs.Has("jobPosting"))
+		assert.True(t, errs.Has("freelancer"))
+		assert.True(t, errs.Has("message"))
+	}
+
+	interview, err := service.Invite(context.Background(), Invitation{JobPostingId: "j1", FreelancerId: "~01", Message: "Let's talk"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusPending, interview.Status)
+	}
+	if reqs := srv.Requests("inviteToInterview"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"jobPostingId": "j1", "freelancerId": "~01", "message": "Let's talk"}, reqs[0].Variables["input"])
+	}
+}
+
+func TestInvitations(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Handle("interviewInvitations", func(req graphqltest.Request) (interface{}, error) {
+		var vars struct{ Pagination graphql.Pagination }
+		req.Decode(&vars)
+		if vars.Pagination.After == "" {
+			return graphqltest.Raw(`{"interviewInvitations": {"edges": [{"node": {"id": "i1"}}], "pageInfo": {"hasNextPage": true, "endCursor": "i1"}}}`), nil
+		}
+		return graphqltest.Raw(`{"interviewInvitations": {"edges": [{"node": {"id": "i2"}}], "pageInfo": {"hasNextPage": false}}}`), nil
+	})
+
+	interviews, err := NewService(srv.Client()).Invitations(context.Background(), "j1")
+	if assert.NoError(t, err) && assert.Len(t, interviews, 2) {
+		assert.Equal(t, "i2", interviews[1].Id)
+	}
+	assert.Len(t, srv.Requests("interviewInvitations"), 2)
+}
+
+func TestInviteAll(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("interviewInvitations", `{"interviewInvitations": {"edges": [{"node": {"id": "i0", "freelancerId": "~invited"}}]}}`)
+	srv.Respond("clientProposals", `{"clientProposals": {"totalCount": 1, "edges": [{"node": {"id": "p1", "freelancer": {"id": "~applied"}}}]}}`)
+	srv.Handle("inviteToInterview", func(req graphqltest.Request) (interface{}, error) {
+		var vars struct{ Input Invitation }
+		req.Decode(&vars)
+		if vars.Input.FreelancerId == "~bad" {
+			return nil, errors.New("freelancer is not available")
+		}
+		return map[string]interface{}{"inviteToInterview": map[string]interface{}{"id": "i-" + vars.Input.FreelancerId, "freelancerId": vars.Input.FreelancerId}}, nil
+	})
+	service := NewService(srv.Client())
+
+	_, err := service.InviteAll(context.Background(), BulkInvitation{JobPostingId: "j1"})
+	assert.EqualError(t, err, "upwork: invalid input: message: is missing; freelancers: are missing")
+
+	results, err := service.InviteAll(context.Background(), BulkInvitation{
+		JobPostingId:  "j1",
+		FreelancerIds: []string{"~a", "~invited", "~applied", "~a", "~bad", "~b"},
+		Message:       "Let's talk",
+	})
+	var inviteErr *InviteError
+	if assert.True(t, errors.As(err, &inviteErr)) {
+		assert.Equal(t, 1, inviteErr.Failed)
+		assert.Equal(t, 6, inviteErr.Total)
+	}
+	if assert.Len(t, results, 6) {
+		assert.Equal(t, "i-~a", results[0].Interview.Id)
+		assert.Equal(t, SkippedAlreadyInvited, results[1].Reason)
+		assert.Equal(t, SkippedAlreadyApplied, results[2].Reason)
+		assert.Equal(t, SkippedDuplicate, results[3].Reason)
+		assert.Equal(t, InviteFailed, results[4].Status)
+	}
+	assert.Equal(t, []string{"~a", "~b"}, results.Invited())
+	assert.Equal(t, []string{"~bad"}, results.Failed())
+	assert.Len(t, srv.Requests("inviteToInterview"), 3)
+	if reqs := srv.Requests("clientProposals"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, "j1", reqs[0].Variables["jobPostingId"])
+	}
+}
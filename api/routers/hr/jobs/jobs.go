@@ -37,25 +37,25 @@ func New(c *api.ApiClient) *a {
 
 // Get list of jobs
 func (r a) GetList(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/jobs.GetList")
 }
 
 // Get specific job by key
 func (r a) GetSpecific(key string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/jobs.GetSpecific")
 }
 
 // Post a new job
 func (r a) PostJob(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/jobs.PostJob")
 }
 
 // Edit existent job
 func (r a) EditJob(key string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/jobs.EditJob")
 }
 
 // Delete existent job
 func (r a) DeleteJob(key string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/jobs.DeleteJob")
 }
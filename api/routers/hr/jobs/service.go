@@ -0,0 +1,362 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Limits of a job posting
+const (
+	MaxTitleLength       = 100
+	MaxDescriptionLength = 50000
+	MaxSkills            = 15
+	MaxQuestions         = 5
+)
+
+// Type of the budget
+type JobType string
+
+const (
+	JobTypeHourly     JobType = "HOURLY"
+	JobTypeFixedPrice JobType = "FIXED_PRICE"
+)
+
+// Expected duration of a project
+type Duration string
+
+const (
+	DurationWeek     Duration = "WEEK"
+	DurationMonth    Duration = "MONTH"
+	DurationQuarter  Duration = "QUARTER"
+	DurationSemester Duration = "SEMESTER"
+	DurationOngoing  Duration = "ONGOING"
+)
+
+var durations = map[Duration]bool{
+	DurationWeek: true, DurationMonth: true, DurationQuarter: true, DurationSemester: true, DurationOngoing: true,
+}
+
+// Visibility of a job posting
+type Visibility string
+
+const (
+	VisibilityPublic     Visibility = "PUBLIC"
+	VisibilityPrivate    Visibility = "PRIVATE"
+	VisibilityInviteOnly Visibility = "INVITE_ONLY"
+)
+
+var visibilities = map[Visibility]bool{VisibilityPublic: true, VisibilityPrivate: true, VisibilityInviteOnly: true}
+
+// Status of a job posting
+type Status string
+
+const (
+	StatusOpen   Status = "OPEN"
+	StatusClosed Status = "CLOSED"
+)
+
+// Reason of closing a job posting
+type CloseReason string
+
+const (
+	CloseHired       CloseReason = "HIRED"
+	CloseNotNeeded   CloseReason = "NO_LONGER_NEEDED"
+	CloseNoCandidate CloseReason = "NO_SUITABLE_CANDIDATE"
+	CloseDuplicate   CloseReason = "DUPLICATE"
+)
+
+var closeReasons = map[CloseReason]bool{CloseHired: true, CloseNotNeeded: true, CloseNoCandidate: true, CloseDuplicate: true}
+
+// Budget of a job posting, either a fixed amount or an hourly range
+type Budget struct {
+	Type      JobType       `json:"type"`
+	Amount    graphql.Money `json:"amount"`    // fixed price
+	HourlyMin graphql.Money `json:"hourlyMin"` // hourly
+	HourlyMax graphql.Money `json:"hourlyMax"` // hourly, optional
+}
+
+// Screening question asked to the applicants
+type Question struct {
+	Question string `json:"question"`
+}
+
+// Job posting
+type JobPosting struct {
+	Id          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	CategoryId  string     `json:"categoryId"`
+	Skills      []string   `json:"skills"`
+	Budget      Budget     `json:"budget"`
+	Duration    Duration   `json:"duration"`
+	Visibility  Visibility `json:"visibility"` // VisibilityPublic by default
+	Questions   []Question `json:"questions"`
+	ExternalRef string     `json:"externalReference"` // own reference, e.g. an ID in an applicant tracking system
+	Status      Status     `json:"status"`
+	CreatedAt   time.Time  `json:"createdDateTime"`
+}
+
+// Check the job posting before sending it, reports all invalid fields
+func (p JobPosting) Validate() error {
+	var errs graphql.ValidationErrors
+
+	if n := utf8.RuneCountInString(strings.TrimSpace(p.Title)); n == 0 {
+		errs.Add("title", "is missing")
+	} else if n > MaxTitleLength {
+		errs.Add("title", "is longer than %d characters", MaxTitleLength)
+	}
+	if n := utf8.RuneCountInString(strings.TrimSpace(p.Description)); n == 0 {
+		errs.Add("description", "is missing")
+	} else if n > MaxDescriptionLength {
+		errs.Add("description", "is longer than %d characters", MaxDescriptionLength)
+	}
+	if p.CategoryId == "" {
+		errs.Add("category", "is missing")
+	}
+
+	if len(p.Skills) == 0 {
+		errs.Add("skills", "at least one skill is required")
+	} else if len(p.Skills) > MaxSkills {
+		errs.Add("skills", "at most %d skills are allowed", MaxSkills)
+	}
+	seen := make(map[string]bool, len(p.Skills))
+	for _, s := range p.Skills {
+		if key := strings.ToLower(strings.TrimSpace(s)); key == "" {
+			errs.Add("skills", "empty skill")
+		} else if seen[key] {
+			errs.Add("skills", "duplicate skill %q", s)
+		} else {
+			seen[key] = true
+		}
+	}
+
+	switch b := p.Budget; b.Type {
+	case JobTypeFixedPrice:
+		if err := b.Amount.Validate(); err != nil {
+			errs.AddErr("budget.amount", err)
+		}
+	case JobTypeHourly:
+		if err := b.HourlyMin.Validate(); err != nil {
+			errs.AddErr("budget.hourlyMin", err)
+		} else if !b.HourlyMax.IsZero() {
+			if !b.HourlyMax.SameCurrency(b.HourlyMin) {
+				errs.Add("budget.hourlyMax", "must be in %s", b.HourlyMin.CurrencyCode())
+			} else if b.HourlyMax.Cents < b.HourlyMin.Cents {
+				errs.Add("budget.hourlyMax", "is less than the minimum rate")
+			}
+		}
+	default:
+		errs.Add("budget.type", "must be %s or %s", JobTypeHourly, JobTypeFixedPrice)
+	}
+
+	if p.Duration != "" && !durations[p.Duration] {
+		errs.Add("duration", "invalid duration %q", p.Duration)
+	}
+	if p.Visibility != "" && !visibilities[p.Visibility] {
+		errs.Add("visibility", "invalid visibility %q", p.Visibility)
+	}
+
+	if len(p.Questions) > MaxQuestions {
+		errs.Add("questions", "at most %d questions are allowed", MaxQuestions)
+	}
+	for i, q := range p.Questions {
+		if strings.TrimSpace(q.Question) == "" {
+			errs.Add(fmt.Sprintf("questions[%d]", i), "is empty")
+		}
+	}
+
+	return errs.Err()
+}
+
+// Filter of the job posting list, empty fields are not applied
+type Filter struct {
+	Status      Status
+	ExternalRef string
+}
+
+// Page of job postings
+type Page struct {
+	Postings   []JobPosting
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Job posting service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const postingFields = `id title description categoryId skills
+	budget { type amount { rawValue currency } hourlyMin { rawValue currency } hourlyMax { rawValue currency } }
+	duration visibility questions { question } externalReference status createdDateTime`
+
+const jobPostingsQuery = `query jobPostings($filter: JobPostingFilter, $pagination: Pagination) {
+  jobPostings(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + postingFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const jobPostingQuery = `query jobPosting($id: ID!) {
+  jobPosting(id: $id) { ` + postingFields + ` }
+}`
+
+const createJobPostingMutation = `mutation createJobPosting($input: JobPostingInput!) {
+  createJobPosting(input: $input) { ` + postingFields + ` }
+}`
+
+const updateJobPostingMutation = `mutation updateJobPosting($id: ID!, $input: JobPostingInput!) {
+  updateJobPosting(id: $id, input: $input) { ` + postingFields + ` }
+}`
+
+const closeJobPostingMutation = `mutation closeJobPosting($id: ID!, $reason: JobPostingCloseReason!) {
+  closeJobPosting(id: $id, reason: $reason) { ` + postingFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List job postings of the company
+func (s *Service) List(ctx context.Context, filter Filter, page graphql.Pagination) (*Page, error) {
+	var data struct {
+		JobPostings graphql.Connection[JobPosting] `json:"jobPostings"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, jobPostingsQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.JobPostings
+	return &Page{Postings: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get a specific job posting
+func (s *Service) Get(ctx context.Context, id string) (*JobPosting, error) {
+	var data struct {
+		JobPosting *JobPosting `json:"jobPosting"`
+	}
+	if err := graphql.Do(ctx, s.client, jobPostingQuery, map[string]interface{}{"id": id}, &data); err != nil {
+		return nil, err
+	}
+	if data.JobPosting == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.JobPosting, nil
+}
+
+// Post a new job
+func (s *Service) Create(ctx context.Context, p JobPosting) (*JobPosting, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return s.mutate(ctx, createJobPostingMutation, "createJobPosting", map[string]interface{}{"input": p.input()})
+}
+
+// Replace an existing job posting with p, identified by p.Id
+func (s *Service) Edit(ctx context.Context, p JobPosting) (*JobPosting, error) {
+	if p.Id == "" {
+		return nil, fmt.Errorf("upwork: jobs: job posting ID is missing")
+	}
+	if p.Status == StatusClosed {
+		return nil, fmt.Errorf("upwork: jobs: job posting %s is closed", p.Id)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return s.mutate(ctx, updateJobPostingMutation, "updateJobPosting", map[string]interface{}{"id": p.Id, "input": p.input()})
+}
+
+// Close a job posting
+func (s *Service) Close(ctx context.Context, id string, reason CloseReason) (*JobPosting, error) {
+	if id == "" {
+		return nil, fmt.Errorf("upwork: jobs: job posting ID is missing")
+	}
+	if !closeReasons[reason] {
+		return nil, fmt.Errorf("upwork: jobs: invalid reason %q", reason)
+	}
+	return s.mutate(ctx, closeJobPostingMutation, "closeJobPosting", map[string]interface{}{"id": id, "reason": reason})
+}
+
+// Run a mutation returning a job posting
+func (s *Service) mutate(ctx context.Context, mutation string, field string, vars map[string]interface{}) (*JobPosting, error) {
+	var data map[string]*JobPosting
+	if err := graphql.Do(ctx, s.client, mutation, vars, &data); err != nil {
+		return nil, err
+	}
+	if data[field] == nil {
+		return nil, fmt.Errorf("upwork: jobs: %s returned no job posting", field)
+	}
+	return data[field], nil
+}
+
+// Get the JobPostingInput input
+func (p JobPosting) input() map[string]interface{} {
+	visibility := p.Visibility
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+
+	budget := map[string]interface{}{"type": p.Budget.Type}
+	if p.Budget.Type == JobTypeFixedPrice {
+		budget["amount"] = p.Budget.Amount
+	} else {
+		budget["hourlyMin"] = p.Budget.HourlyMin
+		if !p.Budget.HourlyMax.IsZero() {
+			budget["hourlyMax"] = p.Budget.HourlyMax
+		}
+	}
+
+	input := map[string]interface{}{
+		"title":       strings.TrimSpace(p.Title),
+		"description": p.Description,
+		"categoryId":  p.CategoryId,
+		"skills":      p.Skills,
+		"budget":      budget,
+		"visibility":  visibility,
+	}
+	if p.Duration != "" {
+		input["duration"] = p.Duration
+	}
+	if len(p.Questions) > 0 {
+		input["questions"] = p.Questions
+	}
+	if p.ExternalRef != "" {
+		input["externalReference"] = p.ExternalRef
+	}
+	return input
+}
+
+// Get the JobPostingFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if f.Status != "" {
+		vars["status_eq"] = f.Status
+	}
+	if f.ExternalRef != "" {
+		vars["externalReference_eq"] = f.ExternalRef
+	}
+	return vars
+}
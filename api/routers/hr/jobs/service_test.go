@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+const postingJson = `{
+	"id": "j1",
+	"title": "Go developer",
+	"description": "Build an API client",
+	"categoryId": "531770282580668418",
+	"skills": ["Go", "GraphQL"],
+	"budget": {"type": "HOURLY", "amount": null, "hourlyMin": {"rawValue": "30", "currency": "USD"}, "hourlyMax": {"rawValue": "60", "currency": "USD"}},
+	"duration": "MONTH",
+	"visibility": "PUBLIC",
+	"questions": [{"question": "Have you used GraphQL?"}],
+	"status": "OPEN",
+	"createdDateTime": "2023-06-01T10:00:00Z"
+}`
+
+func validPosting() JobPosting {
+	return JobPosting{
+		Title:       "Go developer",
+		Description: "Build an API client",
+		CategoryId:  "531770282580668418",
+		Skills:      []string{"Go", "GraphQL"},
+		Budget:      Budget{Type: JobTypeFixedPrice, Amount: graphql.USD(50000)},
+		Duration:    DurationMonth,
+		Questions:   []Question{{"Have you used GraphQL?"}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, validPosting().Validate())
+
+	p := validPosting()
+	p.Title = strings.Repeat("x", MaxTitleLength+1)
+	p.Description = " "
+	p.Skills = []string{"Go", "go"}
+	p.Budget = Budget{Type: JobTypeHourly, HourlyMin: graphql.USD(3000), HourlyMax: graphql.USD(2000)}
+	p.Visibility = "SECRET"
+	p.Questions = []Question{{""}}
+
+	err := p.Validate()
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		for _, field := range []string{"title", "description", "skills", "budget.hourlyMax", "visibility", "questions[0]"} {
+			assert.True(t, errs.Has(field), field)
+		}
+		assert.False(t, errs.Has("category"))
+		assert.Len(t, errs, 6)
+	}
+
+	p = validPosting()
+	p.Budget = Budget{}
+	assert.EqualError(t, p.Validate(), "upwork: invalid input: budget.type: must be HOURLY or FIXED_PRICE")
+	p.Budget = Budget{Type: JobTypeFixedPrice}
+	assert.EqualError(t, p.Validate(), "upwork: invalid input: budget.amount: amount must be positive, got 0.00 USD")
+}
+
+func TestCreate(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("createJobPosting", `{"createJobPosting": `+postingJson+`}`)
+	service := NewService(srv.Client())
+
+	p := validPosting()
+	p.Skills = nil
+	_, err := service.Create(context.Background(), p)
+	assert.Error(t, err)
+	assert.Empty(t, srv.Requests("createJobPosting"))
+
+	posting, err := service.Create(context.Background(), validPosting())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "j1", posting.Id)
+		assert.Equal(t, graphql.USD(3000), posting.Budget.HourlyMin)
+		assert.True(t, posting.Budget.Amount.IsZero())
+		assert.Equal(t, StatusOpen, posting.Status)
+	}
+	if reqs := srv.Requests("createJobPosting"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"title":       "Go developer",
+			"description": "Build an API client",
+			"categoryId":  "531770282580668418",
+			"skills":      []interface{}{"Go", "GraphQL"},
+			"budget":      map[string]interface{}{"type": "FIXED_PRICE", "amount": map[string]interface{}{"rawValue": "500.00", "currency": "USD"}},
+			"duration":    "MONTH",
+			"visibility":  "PUBLIC",
+			"questions":   []interface{}{map[string]interface{}{"question": "Have you used GraphQL?"}},
+		}, reqs[0].Variables["input"])
+	}
+}
+
+func TestEditAndClose(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("updateJobPosting", `{"updateJobPosting": `+postingJson+`}`)
+	srv.Respond("closeJobPosting", `{"closeJobPosting": {"id": "j1", "status": "CLOSED"}}`)
+	service := NewService(srv.Client())
+
+	_, err := service.Edit(context.Background(), validPosting())
+	assert.Error(t, err)
+	closed := validPosting()
+	closed.Id, closed.Status = "j1", StatusClosed
+	_, err = service.Edit(context.Background(), closed)
+	assert.Error(t, err)
+
+	p := validPosting()
+	p.Id = "j1"
+	_, err = service.Edit(context.Background(), p)
+	assert.NoError(t, err)
+	if reqs := srv.Requests("updateJobPosting"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, "j1", reqs[0].Variables["id"])
+	}
+
+	_, err = service.Close(context.Background(), "j1", "BORED")
+	assert.Error(t, err)
+	posting, err := service.Close(context.Background(), "j1", CloseHired)
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusClosed, posting.Status)
+	}
+}
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("jobPostings", `{"jobPostings": {"totalCount": 1, "edges": [{"node": `+postingJson+`}], "pageInfo": {"hasNextPage": false, "endCursor": "j1"}}}`)
+
+	page, err := NewService(srv.Client()).List(context.Background(), Filter{Status: StatusOpen}, graphql.Pagination{})
+	if assert.NoError(t, err) && assert.Len(t, page.Postings, 1) {
+		assert.Equal(t, []string{"Go", "GraphQL"}, page.Postings[0].Skills)
+		assert.Equal(t, DurationMonth, page.Postings[0].Duration)
+	}
+	if reqs := srv.Requests("jobPostings"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"status_eq": "OPEN"}, reqs[0].Variables["filter"])
+	}
+}
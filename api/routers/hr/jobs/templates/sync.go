@@ -0,0 +1,203 @@
+// Job posting templates for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package templates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/hr/jobs"
+)
+
+// Change of a field of a job posting
+type Change struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Field, c.Old, c.New)
+}
+
+// Changes of a job posting
+type Changes []Change
+
+func (c Changes) String() string {
+	lines := make([]string, len(c))
+	for i, ch := range c {
+		lines[i] = "~ " + ch.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Get the changes turning the existing job posting into the desired one
+func Diff(existing jobs.JobPosting, desired jobs.JobPosting) Changes {
+	var changes Changes
+	compare := func(field string, old string, new string) {
+		if old != new {
+			changes = append(changes, Change{Field: field, Old: old, New: new})
+		}
+	}
+
+	compare("title", strings.TrimSpace(existing.Title), strings.TrimSpace(desired.Title))
+	compare("description", strings.TrimSpace(existing.Description), strings.TrimSpace(desired.Description))
+	compare("category", existing.CategoryId, desired.CategoryId)
+	compare("skills", skillSet(existing.Skills), skillSet(desired.Skills))
+	compare("budget", budget(existing.Budget), budget(desired.Budget))
+	compare("duration", string(existing.Duration), string(desired.Duration))
+	compare("visibility", string(visibility(existing.Visibility)), string(visibility(desired.Visibility)))
+	compare("questions", questions(existing.Questions), questions(desired.Questions))
+
+	return changes
+}
+
+// Action taken by a sync
+type SyncAction string
+
+const (
+	SyncCreated   SyncAction = "created"
+	SyncUpdated   SyncAction = "updated"
+	SyncUnchanged SyncAction = "unchanged"
+	SyncFailed    SyncAction = "failed"
+)
+
+// Result of a synced template
+type SyncResult struct {
+	Ref     string
+	Action  SyncAction // the action which would be taken in a dry run
+	Posting *jobs.JobPosting
+	Changes Changes // of an updated posting
+	Err     error
+}
+
+// Create or update the open job postings to match the templates, the postings are
+// looked up by their external reference, so running a sync again changes nothing.
+// The templates are resolved against the taxonomy first, nothing is synced if any of
+// them is invalid. Nothing is sent if dryRun is set.
+func Sync(ctx context.Context, s *jobs.Service, taxonomy *Taxonomy, templates []Template, dryRun bool) ([]SyncResult, error) {
+	if taxonomy == nil {
+		return nil, fmt.Errorf("upwork: templates: taxonomy is required")
+	}
+	templates, err := taxonomy.Resolve(templates)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SyncResult, len(templates))
+	failed := 0
+	for i, t := range templates {
+		results[i] = syncTemplate(ctx, s, t, dryRun)
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("upwork: templates: sync failed for %d of %d templates", failed, len(templates))
+	}
+	return results, nil
+}
+
+func syncTemplate(ctx context.Context, s *jobs.Service, t Template, dryRun bool) SyncResult {
+	res := SyncResult{Ref: t.Ref}
+	fail := func(err error) SyncResult {
+		res.Action, res.Err = SyncFailed, err
+		return res
+	}
+
+	if t.Ref == "" {
+		return fail(fmt.Errorf("upwork: templates: reference is missing"))
+	}
+	desired := t.Posting
+	desired.ExternalRef = t.Ref
+
+	page, err := s.List(ctx, jobs.Filter{Status: jobs.StatusOpen, ExternalRef: t.Ref}, graphql.Pagination{First: 2})
+	if err != nil {
+		return fail(err)
+	}
+
+	switch len(page.Postings) {
+	case 0:
+		res.Action = SyncCreated
+		if dryRun {
+			res.Posting = &desired
+			return res
+		}
+		posting, err := s.Create(ctx, desired)
+		if err != nil {
+			return fail(err)
+		}
+		res.Posting = posting
+	case 1:
+		existing := page.Postings[0]
+		res.Posting = &existing
+		if res.Changes = Diff(existing, desired); len(res.Changes) == 0 {
+			res.Action = SyncUnchanged
+			return res
+		}
+		res.Action = SyncUpdated
+		if dryRun {
+			return res
+		}
+		desired.Id = existing.Id
+		posting, err := s.Edit(ctx, desired)
+		if err != nil {
+			return fail(err)
+		}
+		res.Posting = posting
+	default:
+		return fail(fmt.Errorf("upwork: templates: more than one open job posting with reference %q", t.Ref))
+	}
+
+	return res
+}
+
+func skillSet(skills []string) string {
+	set := make([]string, len(skills))
+	for i, s := range skills {
+		set[i] = strings.ToLower(strings.TrimSpace(s))
+	}
+	sort.Strings(set)
+	return strings.Join(set, ", ")
+}
+
+func budget(b jobs.Budget) string {
+	if b.Type == jobs.JobTypeFixedPrice {
+		return fmt.Sprintf("%s %s", b.Type, b.Amount)
+	}
+	if b.HourlyMax.IsZero() {
+		return fmt.Sprintf("%s %s", b.Type, b.HourlyMin)
+	}
+	return fmt.Sprintf("%s %s - %s", b.Type, b.HourlyMin, b.HourlyMax)
+}
+
+func visibility(v jobs.Visibility) jobs.Visibility {
+	if v == "" {
+		return jobs.VisibilityPublic
+	}
+	return v
+}
+
+func questions(qs []jobs.Question) string {
+	texts := make([]string, len(qs))
+	for i, q := range qs {
+		texts[i] = strings.TrimSpace(q.Question)
+	}
+	return strings.Join(texts, " | ")
+}
@@ -0,0 +1,132 @@
+// Job posting templates for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package templates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Number of the skills requested at once
+const skillsPageSize = 500
+
+// Category of the ontology
+type Category struct {
+	Id            string     `json:"id"`
+	Label         string     `json:"preferredLabel"`
+	Subcategories []Category `json:"subcategories"`
+}
+
+// Taxonomy of the categories and skills the job postings are validated against
+type Taxonomy struct {
+	categories map[string]string // lowercase label or lowercase ID to ID
+	skills     map[string]string // lowercase label to label
+}
+
+const ontologyCategoriesQuery = `query ontologyCategories {
+  ontologyCategories { id preferredLabel subcategories { id preferredLabel } }
+}`
+
+const ontologySkillsQuery = `query ontologySkills($limit: Int!, $offset: Int!) {
+  ontologySkills(limit: $limit, offset: $offset) { id preferredLabel }
+}`
+
+// Create a taxonomy of specific categories and skills
+func NewTaxonomy(categories []Category, skills []string) *Taxonomy {
+	t := &Taxonomy{categories: make(map[string]string), skills: make(map[string]string, len(skills))}
+	var add func(cs []Category)
+	add = func(cs []Category) {
+		for _, c := range cs {
+			t.categories[strings.ToLower(c.Id)] = c.Id
+			t.categories[strings.ToLower(c.Label)] = c.Id
+			add(c.Subcategories)
+		}
+	}
+	add(categories)
+	for _, s := range skills {
+		t.skills[strings.ToLower(s)] = s
+	}
+	return t
+}
+
+// Load the taxonomy from the ontology of GraphQL API
+func LoadTaxonomy(ctx context.Context, c *api.ApiClient) (*Taxonomy, error) {
+	var categories struct {
+		OntologyCategories []Category `json:"ontologyCategories"`
+	}
+	if err := graphql.Do(ctx, c, ontologyCategoriesQuery, nil, &categories); err != nil {
+		return nil, err
+	}
+
+	var skills []string
+	for offset := 0; ; offset += skillsPageSize {
+		var data struct {
+			OntologySkills []struct {
+				Label string `json:"preferredLabel"`
+			} `json:"ontologySkills"`
+		}
+		vars := map[string]interface{}{"limit": skillsPageSize, "offset": offset}
+		if err := graphql.Do(ctx, c, ontologySkillsQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		for _, s := range data.OntologySkills {
+			skills = append(skills, s.Label)
+		}
+		if len(data.OntologySkills) < skillsPageSize {
+			break
+		}
+	}
+
+	return NewTaxonomy(categories.OntologyCategories, skills), nil
+}
+
+// Validate the templates against the taxonomy, the categories given by their
+// labels are replaced by IDs and the skills are spelled as in the taxonomy
+func (t *Taxonomy) Resolve(templates []Template) ([]Template, error) {
+	var errs graphql.ValidationErrors
+	resolved := make([]Template, len(templates))
+	for i, tmpl := range templates {
+		prefix := fmt.Sprintf("jobs[%s]", tmpl.Ref)
+		p := tmpl.Posting
+
+		if id, ok := t.categories[strings.ToLower(strings.TrimSpace(p.CategoryId))]; ok {
+			p.CategoryId = id
+		} else {
+			errs.Add(prefix+".category", "unknown category %q", p.CategoryId)
+		}
+
+		skills := make([]string, len(p.Skills))
+		for j, s := range p.Skills {
+			if label, ok := t.skills[strings.ToLower(strings.TrimSpace(s))]; ok {
+				skills[j] = label
+			} else {
+				errs.Add(prefix+".skills", "unknown skill %q", s)
+				skills[j] = s
+			}
+		}
+		p.Skills = skills
+
+		resolved[i] = Template{Ref: tmpl.Ref, Posting: p}
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
@@ -0,0 +1,230 @@
+// Job posting templates for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+
+// Package templates defines job postings declaratively in YAML files and keeps
+// the postings in sync with them, e.g.
+//
+//	variables:
+//	  stack: Go
+//	defaults:
+//	  category: Web Development
+//	  duration: month
+//	  budget: {type: hourly, hourly_min: 30, hourly_max: 60}
+//	jobs:
+//	  - ref: backend-weekly
+//	    title: ${stack} developer
+//	    description: We are looking for a ${stack} developer...
+//	    skills: [Go, GraphQL]
+//	    questions:
+//	      - Have you used ${stack} in production?
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/hr/jobs"
+)
+
+var variableRe = regexp.MustCompile(`\$\{\s*(\w+)\s*\}`)
+
+// Budget of a job as written in a file
+type Budget struct {
+	Type      string `yaml:"type"` // hourly or fixed_price
+	Amount    string `yaml:"amount"`
+	HourlyMin string `yaml:"hourly_min"`
+	HourlyMax string `yaml:"hourly_max"`
+	Currency  string `yaml:"currency"`
+}
+
+// Job as written in a file, empty fields are taken from the defaults
+type Job struct {
+	Ref         string   `yaml:"ref"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Category    string   `yaml:"category"` // ID or label
+	Skills      []string `yaml:"skills"`
+	Budget      *Budget  `yaml:"budget"`
+	Duration    string   `yaml:"duration"`
+	Visibility  string   `yaml:"visibility"`
+	Questions   []string `yaml:"questions"`
+}
+
+// File of job templates
+type File struct {
+	Variables map[string]string `yaml:"variables"` // default values of the variables
+	Defaults  Job               `yaml:"defaults"`
+	Jobs      []Job             `yaml:"jobs"`
+}
+
+// Template is a job posting defined in a file, identified by its external reference
+type Template struct {
+	Ref     string
+	Posting jobs.JobPosting
+}
+
+// Load the templates from a YAML file, vars override the variables defined in the file
+func Load(fn string, vars map[string]string) ([]Template, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := Parse(data, vars)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	return templates, nil
+}
+
+// Parse the templates from YAML, vars override the variables defined in the data.
+// All invalid fields of all jobs are reported as graphql.ValidationErrors.
+func Parse(data []byte, vars map[string]string) ([]Template, error) {
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("upwork: templates: %w", err)
+	}
+
+	values := make(map[string]string, len(f.Variables)+len(vars))
+	for k, v := range f.Variables {
+		values[k] = v
+	}
+	for k, v := range vars {
+		values[k] = v
+	}
+
+	var errs graphql.ValidationErrors
+	templates := make([]Template, 0, len(f.Jobs))
+	refs := make(map[string]bool, len(f.Jobs))
+	for i, job := range f.Jobs {
+		prefix := fmt.Sprintf("jobs[%d]", i)
+		if job.Ref == "" {
+			errs.Add(prefix+".ref", "is missing")
+		} else if refs[job.Ref] {
+			errs.Add(prefix+".ref", "duplicate reference %q", job.Ref)
+		} else {
+			prefix = fmt.Sprintf("jobs[%s]", job.Ref)
+		}
+		refs[job.Ref] = true
+
+		posting, jobErrs := f.Defaults.merge(job).posting(values)
+		if err := posting.Validate(); err != nil {
+			var perrs graphql.ValidationErrors
+			if errors.As(err, &perrs) {
+				jobErrs = append(jobErrs, perrs...)
+			}
+		}
+		for _, e := range jobErrs {
+			errs.Add(prefix+"."+e.Field, "%s", e.Message)
+		}
+		templates = append(templates, Template{Ref: job.Ref, Posting: posting})
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Get the job with empty fields taken from the defaults
+func (d Job) merge(job Job) Job {
+	if job.Title == "" {
+		job.Title = d.Title
+	}
+	if job.Description == "" {
+		job.Description = d.Description
+	}
+	if job.Category == "" {
+		job.Category = d.Category
+	}
+	if job.Skills == nil {
+		job.Skills = d.Skills
+	}
+	if job.Budget == nil {
+		job.Budget = d.Budget
+	}
+	if job.Duration == "" {
+		job.Duration = d.Duration
+	}
+	if job.Visibility == "" {
+		job.Visibility = d.Visibility
+	}
+	if job.Questions == nil {
+		job.Questions = d.Questions
+	}
+	return job
+}
+
+// Get the job posting with the variables substituted
+func (job Job) posting(vars map[string]string) (jobs.JobPosting, graphql.ValidationErrors) {
+	var errs graphql.ValidationErrors
+	expand := func(field string, s string) string {
+		return variableRe.ReplaceAllStringFunc(s, func(m string) string {
+			name := variableRe.FindStringSubmatch(m)[1]
+			v, ok := vars[name]
+			if !ok {
+				errs.Add(field, "undefined variable %q", name)
+			}
+			return v
+		})
+	}
+
+	p := jobs.JobPosting{
+		Title:       strings.TrimSpace(expand("title", job.Title)),
+		Description: strings.TrimSpace(expand("description", job.Description)),
+		CategoryId:  expand("category", job.Category),
+		Duration:    jobs.Duration(strings.ToUpper(expand("duration", job.Duration))),
+		Visibility:  jobs.Visibility(strings.ToUpper(expand("visibility", job.Visibility))),
+		ExternalRef: job.Ref,
+	}
+	for _, s := range job.Skills {
+		p.Skills = append(p.Skills, expand("skills", s))
+	}
+	for i, q := range job.Questions {
+		p.Questions = append(p.Questions, jobs.Question{Question: expand(fmt.Sprintf("questions[%d]", i), q)})
+	}
+
+	if b := job.Budget; b != nil {
+		currency := strings.ToUpper(expand("budget.currency", b.Currency))
+		if currency == "" {
+			currency = graphql.DefaultCurrency
+		}
+		money := func(field string, s string) graphql.Money {
+			if s = expand(field, s); s == "" {
+				return graphql.Money{}
+			}
+			m, err := graphql.NewMoney(s, currency)
+			if err != nil {
+				errs.Add(field, "invalid amount %q", s)
+			}
+			return m
+		}
+
+		p.Budget = jobs.Budget{
+			Type:      jobs.JobType(strings.ToUpper(expand("budget.type", b.Type))),
+			Amount:    money("budget.amount", b.Amount),
+			HourlyMin: money("budget.hourlyMin", b.HourlyMin),
+			HourlyMax: money("budget.hourlyMax", b.HourlyMax),
+		}
+	}
+
+	return p, errs
+}
@@ -0,0 +1,269 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/hr/jobs"
+)
+
+const templatesYaml = `
+variables:
+  stack: Go
+  level: Senior
+defaults:
+  category: Web Development
+  duration: month
+  budget: {type: hourly, hourly_min: 30, hourly_max: 60}
+  questions:
+    - Have you used ${stack} in production?
+jobs:
+  - ref: backend
+    title: ${level} ${stack} developer
+    description: |
+      We are looking for a ${stack} developer.
+    skills: [go, GraphQL]
+  - ref: frontend
+    title: Frontend developer
+    description: Build the UI.
+    category: "531770282584862733"
+    skills: [React]
+    budget: {type: fixed_price, amount: 500.50, currency: eur}
+    visibility: invite_only
+    questions: []
+`
+
+func testTaxonomy() *Taxonomy {
+	return NewTaxonomy([]Category{
+		{Id: "531770282580668418", Label: "Web Development", Subcategories: []Category{{Id: "531770282584862733", Label: "Front-End Development"}}},
+	}, []string{"Go", "GraphQL", "React"})
+}
+
+func TestParse(t *testing.T) {
+	templates, err := Parse([]byte(templatesYaml), map[string]string{"level": "Lead"})
+	if !assert.NoError(t, err) || !assert.Len(t, templates, 2) {
+		return
+	}
+
+	backend := templates[0].Posting
+	assert.Equal(t, "backend", templates[0].Ref)
+	assert.Equal(t, "backend", backend.ExternalRef)
+	assert.Equal(t, "Lead Go developer", backend.Title)
+	assert.Equal(t, "We are looking for a Go developer.", backend.Description)
+	assert.Equal(t, "Web Development", backend.CategoryId)
+	assert.Equal(t, jobs.DurationMonth, backend.Duration)
+	assert.Equal(t, jobs.Budget{Type: jobs.JobTypeHourly, HourlyMin: graphql.USD(3000), HourlyMax: graphql.USD(6000)}, backend.Budget)
+	assert.Equal(t, []jobs.Question{{Question: "Have you used Go in production?"}}, backend.Questions)
+
+	frontend := templates[1].Posting
+	assert.Equal(t, jobs.Budget{Type: jobs.JobTypeFixedPrice, Amount: graphql.Money{Cents: 50050, Currency: "EUR"}}, frontend.Budget)
+	assert.Equal(t, jobs.VisibilityInviteOnly, frontend.Visibility)
+	assert.Empty(t, frontend.Questions)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse([]byte(`
+jobs:
+  - ref: a
+    title: ${missing} developer
+    description: x
+    category: c
+    skills: [Go]
+    budget: {type: hourly, hourly_min: abc}
+  - ref: a
+    description: x
+    category: c
+    skills: [Go]
+    budget: {type: fixed_price, amount: 10}
+`), nil)
+
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.True(t, errs.Has("jobs[a].title"))
+		assert.True(t, errs.Has("jobs[a].budget.hourlyMin"))
+		assert.True(t, errs.Has("jobs[1].ref"))
+		assert.True(t, errs.Has("jobs[1].title"))
+	}
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestResolve(t *testing.T) {
+	templates, err := Parse([]byte(templatesYaml), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resolved, err := testTaxonomy().Resolve(templates)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "531770282580668418", resolved[0].Posting.CategoryId)
+		assert.Equal(t, []string{"Go", "GraphQL"}, resolved[0].Posting.Skills)
+		assert.Equal(t, "531770282584862733", resolved[1].Posting.CategoryId)
+	}
+	assert.Equal(t, "Web Development", templates[0].Posting.CategoryId)
+
+	templates[0].Posting.Skills = []string{"Go", "Cobol"}
+	templates[1].Posting.CategoryId = "Cooking"
+	_, err = testTaxonomy().Resolve(templates)
+	assert.EqualError(t, err, `upwork: invalid input: jobs[backend].skills: unknown skill "Cobol"; jobs[frontend].category: unknown category "Cooking"`)
+}
+
+func TestLoadTaxonomy(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("ontologyCategories", `{"ontologyCategories": [{"id": "1", "preferredLabel": "Web Development", "subcategories": [{"id": "Cat-2", "preferredLabel": "Front-End Development"}]}]}`)
+	srv.Respond("ontologySkills", `{"ontologySkills": [{"id": "10", "preferredLabel": "Go"}]}`)
+
+	taxonomy, err := LoadTaxonomy(context.Background(), srv.Client())
+	if assert.NoError(t, err) {
+		resolved, err := taxonomy.Resolve([]Template{{Ref: "a", Posting: jobs.JobPosting{CategoryId: "front-end development", Skills: []string{"GO"}}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Cat-2", resolved[0].Posting.CategoryId)
+			assert.Equal(t, []string{"Go"}, resolved[0].Posting.Skills)
+		}
+
+		// mixed-case IDs are resolved in any case
+		for _, id := range []string{"Cat-2", "cat-2"} {
+			resolved, err := taxonomy.Resolve([]Template{{Ref: "a", Posting: jobs.JobPosting{CategoryId: id}}})
+			if assert.NoError(t, err, id) {
+				assert.Equal(t, "Cat-2", resolved[0].Posting.CategoryId, id)
+			}
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	existing := jobs.JobPosting{
+		Title:     "Go developer",
+		Skills:    []string{"GraphQL", "Go"},
+		Budget:    jobs.Budget{Type: jobs.JobTypeHourly, HourlyMin: graphql.USD(3000)},
+		Questions: []jobs.Question{{Question: "Why?"}},
+	}
+	desired := existing
+	desired.Skills = []string{"go", "graphql"}
+	desired.Visibility = jobs.VisibilityPublic
+	assert.Empty(t, Diff(existing, desired))
+
+	desired.Title = "Senior Go developer"
+	desired.Budget.HourlyMax = graphql.USD(6000)
+	assert.Equal(t, Changes{
+		{Field: "title", Old: "Go developer", New: "Senior Go developer"},
+		{Field: "budget", Old: "HOURLY 30.00 USD", New: "HOURLY 30.00 USD - 60.00 USD"},
+	}, Diff(existing, desired))
+}
+
+// stand-in keeping the job postings in memory
+type postingServer struct {
+	mu       sync.Mutex
+	postings []jobs.JobPosting
+}
+
+func (s *postingServer) register(srv *graphqltest.Server) {
+	srv.Handle("jobPostings", func(req graphqltest.Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var vars struct{ Filter map[string]string }
+		req.Decode(&vars)
+		edges := []interface{}{}
+		for _, p := range s.postings {
+			if p.ExternalRef == vars.Filter["externalReference_eq"] && p.Status == jobs.StatusOpen {
+				edges = append(edges, map[string]interface{}{"node": p})
+			}
+		}
+		return map[string]interface{}{"jobPostings": map[string]interface{}{"totalCount": len(edges), "edges": edges}}, nil
+	})
+	save := func(field string) graphqltest.Handler {
+		return func(req graphqltest.Request) (interface{}, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			var vars struct {
+				Id    string
+				Input json.RawMessage
+			}
+			req.Decode(&vars)
+			var p jobs.JobPosting
+			json.Unmarshal(vars.Input, &p)
+			p.Status = jobs.StatusOpen
+			if vars.Id == "" {
+				p.Id = string(rune('A' + len(s.postings)))
+				s.postings = append(s.postings, p)
+			}
+			for i := range s.postings {
+				if s.postings[i].Id == vars.Id {
+					p.Id = vars.Id
+					s.postings[i] = p
+				}
+			}
+			return map[string]interface{}{field: p}, nil
+		}
+	}
+	srv.Handle("createJobPosting", save("createJobPosting"))
+	srv.Handle("updateJobPosting", save("updateJobPosting"))
+}
+
+func TestSync(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	(&postingServer{}).register(srv)
+	service := jobs.NewService(srv.Client())
+	ctx := context.Background()
+
+	templates, err := Parse([]byte(templatesYaml), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	taxonomy := testTaxonomy()
+
+	actions := func(results []SyncResult) []SyncAction {
+		var a []SyncAction
+		for _, r := range results {
+			assert.NoError(t, r.Err)
+			a = append(a, r.Action)
+		}
+		return a
+	}
+
+	results, err := Sync(ctx, service, taxonomy, templates, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncCreated, SyncCreated}, actions(results))
+	assert.Empty(t, srv.Requests("createJobPosting"))
+
+	results, err = Sync(ctx, service, taxonomy, templates, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncCreated, SyncCreated}, actions(results))
+	assert.Equal(t, "A", results[0].Posting.Id)
+
+	// idempotent
+	results, err = Sync(ctx, service, taxonomy, templates, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUnchanged, SyncUnchanged}, actions(results))
+	assert.Len(t, srv.Requests("createJobPosting"), 2)
+
+	templates[0].Posting.Title = "Staff Go developer"
+	results, err = Sync(ctx, service, taxonomy, templates, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUpdated, SyncUnchanged}, actions(results))
+	assert.Equal(t, Changes{{Field: "title", Old: "Senior Go developer", New: "Staff Go developer"}}, results[0].Changes)
+	if reqs := srv.Requests("updateJobPosting"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, "A", reqs[0].Variables["id"])
+	}
+
+	// nothing is synced if a template can not be resolved
+	templates[1].Posting.CategoryId = "Cooking"
+	_, err = Sync(ctx, service, taxonomy, templates, false)
+	assert.EqualError(t, err, `upwork: invalid input: jobs[frontend].category: unknown category "Cooking"`)
+	assert.Len(t, srv.Requests("updateJobPosting"), 1)
+
+	_, err = Sync(ctx, service, nil, templates, true)
+	assert.EqualError(t, err, "upwork: templates: taxonomy is required")
+}
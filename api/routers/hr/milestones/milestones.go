@@ -37,35 +37,35 @@ func New(c *api.ApiClient) *a {
 
 // Get active Milestone for the Contract
 func (r a) GetActiveMilestone(contractId string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.GetActiveMilestone")
 }
 
 // Get all submissions for the active Milestone
 func (r a) GetSubmissions(milestoneId string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.GetSubmissions")
 }
 
 // Create a new Milestone
 func (r a) Create(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.Create")
 }
 
 // Edit an existing Milestone
 func (r a) Edit(milestoneId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.Edit")
 }
 
 // Activate an existing Milestone
 func (r a) Activate(milestoneId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.Activate")
 }
 
 // Approve an existing Milestone
 func (r a) Approve(milestoneId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.Approve")
 }
 
 // Delete an existing Milestone
 func (r a) Delete(milestoneId string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/milestones.Delete")
 }
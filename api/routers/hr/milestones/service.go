@@ -0,0 +1,358 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package milestones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// ErrInvalidTransition is matched by the errors of the actions not allowed in the milestone's status
+var ErrInvalidTransition = errors.New("upwork: milestones: invalid status transition")
+
+// Status of a milestone
+type Status string
+
+const (
+	StatusNotFunded Status = "NOT_FUNDED"
+	StatusActive    Status = "ACTIVE" // funded
+	StatusSubmitted Status = "SUBMITTED"
+	StatusPaid      Status = "PAID"
+)
+
+// Action changing a milestone
+type Action string
+
+const (
+	ActionEdit     Action = "edit"
+	ActionActivate Action = "activate"
+	ActionApprove  Action = "approve"
+	ActionDelete   Action = "delete"
+)
+
+// Statuses in which the actions are allowed
+var transitions = map[Action][]Status{
+	ActionEdit:     {StatusNotFunded, StatusActive},
+	ActionActivate: {StatusNotFunded},
+	ActionApprove:  {StatusActive, StatusSubmitted},
+	ActionDelete:   {StatusNotFunded},
+}
+
+// TransitionError is returned if an action is not allowed in the milestone's status
+type TransitionError struct {
+	MilestoneId string
+	Status      Status
+	Action      Action
+}
+
+func (e *TransitionError) Error() string {
+	if e.Action == ActionApprove && e.Status == StatusNotFunded {
+		return fmt.Sprintf("upwork: milestones: can not approve milestone %s, it is not funded", e.MilestoneId)
+	}
+	return fmt.Sprintf("upwork: milestones: can not %s milestone %s in status %s", e.Action, e.MilestoneId, e.Status)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Milestone of a fixed-price contract
+type Milestone struct {
+	Id          string        `json:"id"`
+	ContractId  string        `json:"contractId"`
+	Description string        `json:"description"`
+	Amount      graphql.Money `json:"depositAmount"`
+	Paid        graphql.Money `json:"paid"`
+	DueDate     graphql.Date  `json:"dueDate"`
+	Status      Status        `json:"state"`
+	SequenceId  int           `json:"sequenceId"`
+	CreatedAt   time.Time     `json:"createdDateTime"`
+}
+
+// Check if an action is allowed in the milestone's status
+func (m Milestone) Can(action Action) error {
+	for _, s := range transitions[action] {
+		if m.Status == s {
+			return nil
+		}
+	}
+	return &TransitionError{MilestoneId: m.Id, Status: m.Status, Action: action}
+}
+
+// Submission of the work done for a milestone
+type Submission struct {
+	Id          string        `json:"id"`
+	MilestoneId string        `json:"milestoneId"`
+	Status      string        `json:"status"`
+	Note        string        `json:"description"`
+	Amount      graphql.Money `json:"amount"`
+	SubmittedAt time.Time     `json:"createdDateTime"`
+}
+
+// Input of a new milestone
+type NewMilestone struct {
+	ContractId  string
+	Description string
+	Amount      graphql.Money
+	DueDate     graphql.Date // optional
+	Fund        bool         // activate the milestone right away
+}
+
+// Changes of a milestone, zero fields are not changed
+type MilestoneEdit struct {
+	Description string
+	Amount      graphql.Money
+	DueDate     graphql.Date
+	Message     string // message to the freelancer
+}
+
+// Approval of a milestone
+type Approval struct {
+	Amount graphql.Money // the milestone's amount if zero
+	Bonus  graphql.Money // optional
+	Note   string
+}
+
+// Milestone service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+
+	now func() time.Time
+}
+
+const milestoneFields = `id contractId description depositAmount { rawValue currency } paid { rawValue currency }
+	dueDate state sequenceId createdDateTime`
+
+const contractMilestonesQuery = `query contractMilestones($contractId: ID!) {
+  contractMilestones(contractId: $contractId) { ` + milestoneFields + ` }
+}`
+
+const milestoneSubmissionsQuery = `query milestoneSubmissions($milestoneId: ID!) {
+  milestoneSubmissions(milestoneId: $milestoneId) {
+    id milestoneId status description amount { rawValue currency } createdDateTime
+  }
+}`
+
+const createMilestoneMutation = `mutation createMilestoneV2($input: CreateMilestoneInput!) {
+  createMilestoneV2(input: $input) { ` + milestoneFields + ` }
+}`
+
+const editMilestoneMutation = `mutation editMilestone($input: EditMilestoneInput!) {
+  editMilestone(input: $input) { ` + milestoneFields + ` }
+}`
+
+const activateMilestoneMutation = `mutation activateMilestone($input: ActivateMilestoneInput!) {
+  activateMilestone(input: $input) { ` + milestoneFields + ` }
+}`
+
+const approveMilestoneMutation = `mutation approveMilestone($input: ApproveMilestoneInput!) {
+  approveMilestone(input: $input) { ` + milestoneFields + ` }
+}`
+
+const deleteMilestoneMutation = `mutation deleteMilestone($input: DeleteMilestoneInput!) {
+  deleteMilestone(input: $input)
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{client: c, now: time.Now}
+}
+
+// Get all milestones of a contract
+func (s *Service) GetMilestones(ctx context.Context, contractId string) ([]Milestone, error) {
+	var data struct {
+		ContractMilestones []Milestone `json:"contractMilestones"`
+	}
+	if err := graphql.Do(ctx, s.client, contractMilestonesQuery, map[string]interface{}{"contractId": contractId}, &data); err != nil {
+		return nil, err
+	}
+	return data.ContractMilestones, nil
+}
+
+// Get active, i.e. funded, milestone of a contract
+func (s *Service) GetActiveMilestone(ctx context.Context, contractId string) (*Milestone, error) {
+	milestones, err := s.GetMilestones(ctx, contractId)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range milestones {
+		if m.Status == StatusActive || m.Status == StatusSubmitted {
+			return &m, nil
+		}
+	}
+	return nil, graphql.ErrNotFound
+}
+
+// Get all submissions for a milestone
+func (s *Service) GetSubmissions(ctx context.Context, milestoneId string) ([]Submission, error) {
+	var data struct {
+		MilestoneSubmissions []Submission `json:"milestoneSubmissions"`
+	}
+	if err := graphql.Do(ctx, s.client, milestoneSubmissionsQuery, map[string]interface{}{"milestoneId": milestoneId}, &data); err != nil {
+		return nil, err
+	}
+	return data.MilestoneSubmissions, nil
+}
+
+// Create a new milestone
+func (s *Service) Create(ctx context.Context, m NewMilestone) (*Milestone, error) {
+	if m.ContractId == "" {
+		return nil, fmt.Errorf("upwork: milestones: contract ID is missing")
+	}
+	if strings.TrimSpace(m.Description) == "" {
+		return nil, fmt.Errorf("upwork: milestones: description is missing")
+	}
+	if err := m.Amount.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.validateDueDate(m.DueDate); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{
+		"contractId":    m.ContractId,
+		"description":   m.Description,
+		"depositAmount": m.Amount,
+		"fund":          m.Fund,
+	}
+	if !m.DueDate.IsZero() {
+		input["dueDate"] = m.DueDate
+	}
+	return s.mutate(ctx, createMilestoneMutation, "createMilestoneV2", input)
+}
+
+// Edit an existing milestone
+func (s *Service) Edit(ctx context.Context, m Milestone, edit MilestoneEdit) (*Milestone, error) {
+	if err := m.Can(ActionEdit); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{"id": m.Id}
+	if edit.Description != "" {
+		input["description"] = edit.Description
+	}
+	if !edit.Amount.IsZero() {
+		if err := edit.Amount.Validate(); err != nil {
+			return nil, err
+		}
+		if !edit.Amount.SameCurrency(m.Amount) {
+			return nil, fmt.Errorf("upwork: milestones: can not change the currency from %s to %s", m.Amount.CurrencyCode(), edit.Amount.CurrencyCode())
+		}
+		input["depositAmount"] = edit.Amount
+	}
+	if !edit.DueDate.IsZero() {
+		if err := s.validateDueDate(edit.DueDate); err != nil {
+			return nil, err
+		}
+		input["dueDate"] = edit.DueDate
+	}
+	if edit.Message != "" {
+		input["message"] = edit.Message
+	}
+	if len(input) == 1 {
+		return nil, fmt.Errorf("upwork: milestones: nothing to edit")
+	}
+	return s.mutate(ctx, editMilestoneMutation, "editMilestone", input)
+}
+
+// Activate, i.e. fund, a milestone
+func (s *Service) Activate(ctx context.Context, m Milestone, message string) (*Milestone, error) {
+	if err := m.Can(ActionActivate); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{"id": m.Id}
+	if message != "" {
+		input["message"] = message
+	}
+	return s.mutate(ctx, activateMilestoneMutation, "activateMilestone", input)
+}
+
+// Approve a funded milestone and release the payment
+func (s *Service) Approve(ctx context.Context, m Milestone, a Approval) (*Milestone, error) {
+	if err := m.Can(ActionApprove); err != nil {
+		return nil, err
+	}
+
+	amount := a.Amount
+	if amount.IsZero() {
+		amount = m.Amount
+	}
+	if err := amount.Validate(); err != nil {
+		return nil, err
+	}
+	if !amount.SameCurrency(m.Amount) {
+		return nil, fmt.Errorf("upwork: milestones: the amount must be in %s", m.Amount.CurrencyCode())
+	}
+	if amount.Cents > m.Amount.Cents {
+		return nil, fmt.Errorf("upwork: milestones: the amount %s exceeds the funded %s", amount, m.Amount)
+	}
+
+	input := map[string]interface{}{"id": m.Id, "paidAmount": amount}
+	if !a.Bonus.IsZero() {
+		if err := a.Bonus.Validate(); err != nil {
+			return nil, err
+		}
+		input["bonusAmount"] = a.Bonus
+	}
+	if a.Note != "" {
+		input["note"] = a.Note
+	}
+	return s.mutate(ctx, approveMilestoneMutation, "approveMilestone", input)
+}
+
+// Delete a not funded milestone
+func (s *Service) Delete(ctx context.Context, m Milestone) error {
+	if err := m.Can(ActionDelete); err != nil {
+		return err
+	}
+
+	var data struct {
+		DeleteMilestone bool `json:"deleteMilestone"`
+	}
+	if err := graphql.Do(ctx, s.client, deleteMilestoneMutation, map[string]interface{}{"input": map[string]interface{}{"id": m.Id}}, &data); err != nil {
+		return err
+	}
+	if !data.DeleteMilestone {
+		return fmt.Errorf("upwork: milestones: milestone %s was not deleted", m.Id)
+	}
+	return nil
+}
+
+// Run a mutation returning a milestone
+func (s *Service) mutate(ctx context.Context, mutation string, field string, input map[string]interface{}) (*Milestone, error) {
+	var data map[string]*Milestone
+	if err := graphql.Do(ctx, s.client, mutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data[field] == nil {
+		return nil, fmt.Errorf("upwork: milestones: %s returned no milestone", field)
+	}
+	return data[field], nil
+}
+
+// Check that the due date is not in the past
+func (s *Service) validateDueDate(d graphql.Date) error {
+	if !d.IsZero() && d.Before(graphql.DateOf(s.now())) {
+		return fmt.Errorf("upwork: milestones: due date %s is in the past", d)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package milestones
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func setupService(t *testing.T) (*Service, *graphqltest.Server) {
+	srv := graphqltest.NewServer(t)
+	service := NewService(srv.Client())
+	service.now = func() time.Time { return time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	return service, srv
+}
+
+func TestGetActiveMilestone(t *testing.T) {
+	service, srv := setupService(t)
+	srv.Respond("contractMilestones", `{"contractMilestones": [
+		{"id": "m1", "contractId": "c1", "description": "Design", "depositAmount": {"rawValue": "100.00", "currency": "USD"}, "paid": {"rawValue": "100.00", "currency": "USD"}, "dueDate": "2023-05-01", "state": "PAID", "sequenceId": 1},
+		{"id": "m2", "contractId": "c1", "description": "Build", "depositAmount": {"rawValue": "250.50", "currency": "USD"}, "paid": {"rawValue": "0", "currency": "USD"}, "dueDate": "2023-07-01", "state": "ACTIVE", "sequenceId": 2}
+	]}`)
+
+	m, err := service.GetActiveMilestone(context.Background(), "c1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "m2", m.Id)
+		assert.Equal(t, graphql.USD(25050), m.Amount)
+		assert.Equal(t, graphql.Date{Year: 2023, Month: time.July, Day: 1}, m.DueDate)
+		assert.Equal(t, StatusActive, m.Status)
+	}
+	if reqs := srv.Requests("contractMilestones"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, "c1", reqs[0].Variables["contractId"])
+	}
+
+	srv.Respond("contractMilestones", `{"contractMilestones": []}`)
+	_, err = service.GetActiveMilestone(context.Background(), "c2")
+	assert.Equal(t, graphql.ErrNotFound, err)
+}
+
+func TestCreate(t *testing.T) {
+	service, srv := setupService(t)
+	srv.Respond("createMilestoneV2", `{"createMilestoneV2": {"id": "m3", "contractId": "c1", "description": "Deploy", "depositAmount": {"rawValue": "75", "currency": "USD"}, "dueDate": "2023-06-30", "state": "NOT_FUNDED"}}`)
+
+	due := graphql.Date{Year: 2023, Month: time.June, Day: 30}
+	for _, m := range []NewMilestone{
+		{Description: "Deploy", Amount: graphql.USD(7500)},
+		{ContractId: "c1", Amount: graphql.USD(7500)},
+		{ContractId: "c1", Description: "Deploy"},
+		{ContractId: "c1", Description: "Deploy", Amount: graphql.USD(7500), DueDate: graphql.Date{Year: 2023, Month: time.May, Day: 31}},
+	} {
+		_, err := service.Create(context.Background(), m)
+		assert.Error(t, err)
+	}
+	assert.Empty(t, srv.Requests("createMilestoneV2"))
+
+	m, err := service.Create(context.Background(), NewMilestone{ContractId: "c1", Description: "Deploy", Amount: graphql.USD(7500), DueDate: due})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "m3", m.Id)
+		assert.Equal(t, StatusNotFunded, m.Status)
+		assert.Equal(t, due, m.DueDate)
+	}
+	if reqs := srv.Requests("createMilestoneV2"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"contractId":    "c1",
+			"description":   "Deploy",
+			"depositAmount": map[string]interface{}{"rawValue": "75.00", "currency": "USD"},
+			"dueDate":       "2023-06-30",
+			"fund":          false,
+		}, reqs[0].Variables["input"])
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	service, srv := setupService(t)
+	srv.Respond("approveMilestone", `{"approveMilestone": {"id": "m1", "state": "PAID", "paid": {"rawValue": "100", "currency": "USD"}}}`)
+	srv.Respond("activateMilestone", `{"activateMilestone": {"id": "m1", "state": "ACTIVE"}}`)
+	srv.Respond("deleteMilestone", `{"deleteMilestone": true}`)
+
+	unfunded := Milestone{Id: "m1", Amount: graphql.USD(10000), Status: StatusNotFunded}
+	_, err := service.Approve(context.Background(), unfunded, Approval{})
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	assert.EqualError(t, err, "upwork: milestones: can not approve milestone m1, it is not funded")
+	assert.Empty(t, srv.Requests("approveMilestone"))
+
+	m, err := service.Activate(context.Background(), unfunded, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusActive, m.Status)
+	}
+
+	funded := Milestone{Id: "m1", Amount: graphql.USD(10000), Status: StatusActive}
+	_, err = service.Activate(context.Background(), funded, "")
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	assert.True(t, errors.Is(service.Delete(context.Background(), funded), ErrInvalidTransition))
+	assert.NoError(t, service.Delete(context.Background(), unfunded))
+
+	_, err = service.Approve(context.Background(), funded, Approval{Amount: graphql.USD(20000)})
+	assert.Error(t, err)
+	_, err = service.Approve(context.Background(), funded, Approval{Amount: graphql.Money{Cents: 100, Currency: "EUR"}})
+	assert.Error(t, err)
+	assert.Empty(t, srv.Requests("approveMilestone"))
+
+	m, err = service.Approve(context.Background(), funded, Approval{Bonus: graphql.USD(500), Note: "thanks"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusPaid, m.Status)
+		assert.Equal(t, graphql.USD(10000), m.Paid)
+	}
+	if reqs := srv.Requests("approveMilestone"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"id":          "m1",
+			"paidAmount":  map[string]interface{}{"rawValue": "100.00", "currency": "USD"},
+			"bonusAmount": map[string]interface{}{"rawValue": "5.00", "currency": "USD"},
+			"note":        "thanks",
+		}, reqs[0].Variables["input"])
+	}
+
+	_, err = service.Edit(context.Background(), Milestone{Id: "m1", Status: StatusPaid}, MilestoneEdit{Description: "new"})
+	assert.True(t, errors.Is(err, ErrInvalidTransition))
+	_, err = service.Edit(context.Background(), funded, MilestoneEdit{})
+	assert.Error(t, err)
+}
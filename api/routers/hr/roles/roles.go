@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get user roles
 func (r a) GetAll() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/roles.GetAll")
 }
 
 // Get by specific user
 func (r a) GetBySpecificUser(reference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/roles.GetBySpecificUser")
 }
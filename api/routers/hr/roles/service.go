@@ -0,0 +1,170 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package roles
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Permission of a user in a team
+type Permission string
+
+const (
+	PermissionAdmin            Permission = "ADMINISTRATION"    // implies all the other permissions
+	PermissionManageRecruiting Permission = "MANAGE_RECRUITING" // job postings, proposals, invitations, offers
+	PermissionManageEmployment Permission = "MANAGE_EMPLOYMENT" // contracts and milestones
+	PermissionManageFinance    Permission = "MANAGE_FINANCE"    // bonuses, payments, submissions
+	PermissionViewFinance      Permission = "VIEW_FINANCE"
+	PermissionManageTeam       Permission = "MANAGE_TEAM"
+)
+
+// Company a team belongs to
+type Company struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Role of a user in a team
+type Role struct {
+	TeamId      string       `json:"teamId"`
+	TeamName    string       `json:"teamName"`
+	Company     Company      `json:"company"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// Check if the role grants a permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission || p == PermissionAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles of a user
+type Roles []Role
+
+// Get the role in a team, nil if the user has no role in it
+func (r Roles) Team(teamId string) *Role {
+	for i := range r {
+		if r[i].TeamId == teamId {
+			return &r[i]
+		}
+	}
+	return nil
+}
+
+// Check if the user has a permission in a team
+func (r Roles) Can(permission Permission, teamId string) bool {
+	role := r.Team(teamId)
+	return role != nil && role.Can(permission)
+}
+
+// Get the teams the user has a permission in
+func (r Roles) Teams(permission Permission) []string {
+	var ids []string
+	for _, role := range r {
+		if role.Can(permission) {
+			ids = append(ids, role.TeamId)
+		}
+	}
+	return ids
+}
+
+// Roles service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+
+	mu    sync.Mutex
+	roles Roles // of the authorized user, cached by Can
+}
+
+const roleFields = `teamId teamName company { id name } permissions`
+
+const companySelectorQuery = `query companySelector {
+  companySelector { items { ` + roleFields + ` } }
+}`
+
+const userRolesQuery = `query userRoles($userId: ID!) {
+  userRoles(userId: $userId) { ` + roleFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{client: c}
+}
+
+// Get the roles of the authorized user, the teams and companies it can act for.
+// The roles are always fetched, and the ones cached by Can are replaced.
+func (s *Service) List(ctx context.Context) (Roles, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.list(ctx)
+}
+
+func (s *Service) list(ctx context.Context) (Roles, error) {
+	var data struct {
+		CompanySelector struct {
+			Items Roles `json:"items"`
+		} `json:"companySelector"`
+	}
+	if err := graphql.Do(ctx, s.client, companySelectorQuery, nil, &data); err != nil {
+		return nil, err
+	}
+	s.roles = data.CompanySelector.Items
+	if s.roles == nil {
+		s.roles = Roles{}
+	}
+	return s.roles, nil
+}
+
+// Get the roles of a specific user
+func (s *Service) ForUser(ctx context.Context, userId string) (Roles, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("upwork: roles: user ID is missing")
+	}
+
+	var data struct {
+		UserRoles Roles `json:"userRoles"`
+	}
+	if err := graphql.Do(ctx, s.client, userRolesQuery, map[string]interface{}{"userId": userId}, &data); err != nil {
+		return nil, err
+	}
+	if data.UserRoles == nil {
+		return Roles{}, nil
+	}
+	return data.UserRoles, nil
+}
+
+// Check if the authorized user has a permission in a team. The roles are fetched by the
+// first call only, one request for any number of checks; call List to see changed roles.
+func (s *Service) Can(ctx context.Context, permission Permission, teamId string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.roles == nil {
+		if _, err := s.list(ctx); err != nil {
+			return false, err
+		}
+	}
+	return s.roles.Can(permission, teamId), nil
+}
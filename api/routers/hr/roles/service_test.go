@@ -0,0 +1,79 @@
+package roles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+const companySelector = `{"companySelector": {"items": [
+	{"teamId": "t1", "teamName": "Engineering", "company": {"id": "c1", "name": "Acme"}, "permissions": ["MANAGE_RECRUITING", "VIEW_FINANCE"]},
+	{"teamId": "t2", "teamName": "Acme", "company": {"id": "c1", "name": "Acme"}, "permissions": ["ADMINISTRATION"]}
+]}}`
+
+func TestRoles(t *testing.T) {
+	roles := Roles{
+		{TeamId: "t1", Permissions: []Permission{PermissionManageRecruiting}},
+		{TeamId: "t2", Permissions: []Permission{PermissionAdmin}},
+	}
+
+	assert.True(t, roles.Can(PermissionManageRecruiting, "t1"))
+	assert.False(t, roles.Can(PermissionManageFinance, "t1"))
+	assert.True(t, roles.Can(PermissionManageFinance, "t2"))
+	assert.False(t, roles.Can(PermissionManageRecruiting, "t3"))
+	assert.Nil(t, roles.Team("t3"))
+	assert.Equal(t, []string{"t1", "t2"}, roles.Teams(PermissionManageRecruiting))
+	assert.Equal(t, []string{"t2"}, roles.Teams(PermissionManageEmployment))
+}
+
+func TestList(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("companySelector", companySelector)
+
+	roles, err := NewService(srv.Client()).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, roles, 2) {
+		assert.Equal(t, Company{Id: "c1", Name: "Acme"}, roles[0].Company)
+		assert.Equal(t, []Permission{PermissionManageRecruiting, PermissionViewFinance}, roles[0].Permissions)
+	}
+}
+
+func TestForUser(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("userRoles", `{"userRoles": null}`)
+	service := NewService(srv.Client())
+
+	_, err := service.ForUser(context.Background(), "")
+	assert.Error(t, err)
+
+	roles, err := service.ForUser(context.Background(), "u1")
+	if assert.NoError(t, err) {
+		assert.Empty(t, roles)
+	}
+	if reqs := srv.Requests("userRoles"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, "u1", reqs[0].Variables["userId"])
+	}
+}
+
+func TestCan(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("companySelector", companySelector)
+	service := NewService(srv.Client())
+
+	ok, err := service.Can(context.Background(), PermissionManageFinance, "t1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = service.Can(context.Background(), PermissionManageFinance, "t2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// the roles are fetched once, List fetches them again
+	assert.Len(t, srv.Requests("companySelector"), 1)
+	_, err = service.List(context.Background())
+	assert.NoError(t, err)
+	service.Can(context.Background(), PermissionManageTeam, "t1")
+	assert.Len(t, srv.Requests("companySelector"), 2)
+}
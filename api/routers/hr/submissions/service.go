@@ -0,0 +1,221 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package submissions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// Limits of a submission
+const (
+	MaxNoteLength = 5000
+	MaxFiles      = 10
+)
+
+// Status of a submission
+type Status string
+
+const (
+	StatusPending  Status = "PENDING"
+	StatusApproved Status = "APPROVED"
+	StatusRejected Status = "REJECTED" // changes requested
+)
+
+// File attached to a submission
+type File struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+	Size int64  `json:"size"` // bytes
+}
+
+// Work submitted for a milestone of a fixed-price contract
+type Submission struct {
+	Id           string        `json:"id"`
+	ContractId   string        `json:"contractId"`
+	MilestoneId  string        `json:"milestoneId"`
+	Status       Status        `json:"status"`
+	Note         string        `json:"description"`
+	Amount       graphql.Money `json:"amount"` // requested
+	Files        []File        `json:"attachments"`
+	Bonus        graphql.Money `json:"bonus"`        // approved
+	RejectReason string        `json:"rejectReason"` // rejected
+	SubmittedAt  time.Time     `json:"createdDateTime"`
+}
+
+// Request of a freelancer to approve the work done for a milestone
+type Request struct {
+	ContractId  string
+	MilestoneId string
+	Amount      graphql.Money
+	Note        string
+	FileIds     []string // uploaded files
+}
+
+// Approval of a submission by a client
+type Approval struct {
+	Bonus graphql.Money // optional
+	Note  string
+}
+
+// Page of submissions
+type Page struct {
+	Submissions []Submission
+	TotalCount  int
+	PageInfo    graphql.PageInfo
+}
+
+// Work submission service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const submissionFields = `id contractId milestoneId status description amount { rawValue currency }
+	attachments { id name url size } bonus { rawValue currency } rejectReason createdDateTime`
+
+const submissionListQuery = `query submissionList($filter: SubmissionFilter, $pagination: Pagination) {
+  submissionList(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + submissionFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const requestApprovalMutation = `mutation requestSubmissionApproval($input: RequestSubmissionApprovalInput!) {
+  requestSubmissionApproval(input: $input) { ` + submissionFields + ` }
+}`
+
+const approveMutation = `mutation approveSubmission($input: ApproveSubmissionInput!) {
+  approveSubmission(input: $input) { ` + submissionFields + ` }
+}`
+
+const rejectMutation = `mutation rejectSubmission($input: RejectSubmissionInput!) {
+  rejectSubmission(input: $input) { ` + submissionFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List the submissions pending approval, of all contracts if no contract is given
+func (s *Service) Pending(ctx context.Context, contractIds []string, page graphql.Pagination) (*Page, error) {
+	var data struct {
+		SubmissionList graphql.Connection[Submission] `json:"submissionList"`
+	}
+	filter := map[string]interface{}{"status_any": []Status{StatusPending}}
+	if len(contractIds) > 0 {
+		filter["contractId_any"] = contractIds
+	}
+	vars := map[string]interface{}{"filter": filter, "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, submissionListQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.SubmissionList
+	return &Page{Submissions: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Submit the work done for a milestone and request the client's approval
+func (s *Service) RequestApproval(ctx context.Context, r Request) (*Submission, error) {
+	var errs graphql.ValidationErrors
+	if r.ContractId == "" {
+		errs.Add("contract", "is missing")
+	}
+	if r.MilestoneId == "" {
+		errs.Add("milestone", "is missing")
+	}
+	if err := r.Amount.Validate(); err != nil {
+		errs.AddErr("amount", err)
+	}
+	if utf8.RuneCountInString(r.Note) > MaxNoteLength {
+		errs.Add("note", "is longer than %d characters", MaxNoteLength)
+	}
+	if len(r.FileIds) > MaxFiles {
+		errs.Add("files", "at most %d files are allowed", MaxFiles)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{"contractId": r.ContractId, "milestoneId": r.MilestoneId, "amount": r.Amount}
+	if r.Note != "" {
+		input["description"] = r.Note
+	}
+	if len(r.FileIds) > 0 {
+		input["attachmentIds"] = r.FileIds
+	}
+	return s.mutate(ctx, requestApprovalMutation, "requestSubmissionApproval", input)
+}
+
+// Approve a pending submission, paying its amount and the optional bonus
+func (s *Service) Approve(ctx context.Context, sub Submission, a Approval) (*Submission, error) {
+	if err := sub.pending("approved"); err != nil {
+		return nil, err
+	}
+
+	input := map[string]interface{}{"submissionId": sub.Id}
+	if !a.Bonus.IsZero() {
+		if err := a.Bonus.Validate(); err != nil {
+			return nil, err
+		}
+		if !sub.Amount.IsZero() && !a.Bonus.SameCurrency(sub.Amount) {
+			return nil, fmt.Errorf("upwork: submissions: bonus must be in %s", sub.Amount.CurrencyCode())
+		}
+		input["bonus"] = a.Bonus
+	}
+	if a.Note != "" {
+		input["note"] = a.Note
+	}
+	return s.mutate(ctx, approveMutation, "approveSubmission", input)
+}
+
+// Reject a pending submission, requesting changes for the reason given to the freelancer
+func (s *Service) Reject(ctx context.Context, sub Submission, reason string) (*Submission, error) {
+	if err := sub.pending("rejected"); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("upwork: submissions: reason is missing")
+	}
+	return s.mutate(ctx, rejectMutation, "rejectSubmission", map[string]interface{}{"submissionId": sub.Id, "reason": reason})
+}
+
+// Run a mutation returning a submission
+func (s *Service) mutate(ctx context.Context, mutation string, field string, input map[string]interface{}) (*Submission, error) {
+	var data map[string]*Submission
+	if err := graphql.Do(ctx, s.client, mutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data[field] == nil {
+		return nil, fmt.Errorf("upwork: submissions: %s returned no submission", field)
+	}
+	return data[field], nil
+}
+
+// Check if the submission can still be reviewed
+func (sub Submission) pending(verb string) error {
+	if sub.Status != StatusPending {
+		return fmt.Errorf("upwork: submissions: submission %s is %s, only a pending submission can be %s", sub.Id, sub.Status, verb)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package submissions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+func TestPending(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("submissionList", `{"submissionList": {"totalCount": 1, "edges": [{"node": {"id": "s1", "contractId": "c1", "milestoneId": "m1", "status": "PENDING",
+		"amount": {"rawValue": "250", "currency": "USD"}, "attachments": [{"id": "f1", "name": "design.pdf", "size": 1024}]}}]}}`)
+	service := NewService(srv.Client())
+
+	page, err := service.Pending(context.Background(), nil, graphql.Pagination{})
+	if assert.NoError(t, err) && assert.Len(t, page.Submissions, 1) {
+		assert.Equal(t, graphql.USD(25000), page.Submissions[0].Amount)
+		assert.Equal(t, []File{{Id: "f1", Name: "design.pdf", Size: 1024}}, page.Submissions[0].Files)
+	}
+	_, err = service.Pending(context.Background(), []string{"c1", "c2"}, graphql.Pagination{})
+	assert.NoError(t, err)
+
+	if reqs := srv.Requests("submissionList"); assert.Len(t, reqs, 2) {
+		assert.Equal(t, map[string]interface{}{"status_any": []interface{}{"PENDING"}}, reqs[0].Variables["filter"])
+		assert.Equal(t, map[string]interface{}{"status_any": []interface{}{"PENDING"}, "contractId_any": []interface{}{"c1", "c2"}}, reqs[1].Variables["filter"])
+	}
+}
+
+func TestRequestApproval(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("requestSubmissionApproval", `{"requestSubmissionApproval": {"id": "s1", "status": "PENDING"}}`)
+	service := NewService(srv.Client())
+
+	_, err := service.RequestApproval(context.Background(), Request{FileIds: make([]string, MaxFiles+1)})
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		for _, field := range []string{"contract", "milestone", "amount", "files"} {
+			assert.True(t, errs.Has(field), field)
+		}
+	}
+	assert.Empty(t, srv.Requests("requestSubmissionApproval"))
+
+	sub, err := service.RequestApproval(context.Background(), Request{ContractId: "c1", MilestoneId: "m1", Amount: graphql.USD(25000), Note: "Done", FileIds: []string{"f1"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, StatusPending, sub.Status)
+	}
+	if reqs := srv.Requests("requestSubmissionApproval"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"contractId":    "c1",
+			"milestoneId":   "m1",
+			"amount":        map[string]interface{}{"rawValue": "250.00", "currency": "USD"},
+			"description":   "Done",
+			"attachmentIds": []interface{}{"f1"},
+		}, reqs[0].Variables["input"])
+	}
+}
+
+func TestReview(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("approveSubmission", `{"approveSubmission": {"id": "s1", "status": "APPROVED", "bonus": {"rawValue": "20", "currency": "USD"}}}`)
+	srv.Respond("rejectSubmission", `{"rejectSubmission": {"id": "s2", "status": "REJECTED", "rejectReason": "Missing sources"}}`)
+	service := NewService(srv.Client())
+	ctx := context.Background()
+	pending := Submission{Id: "s1", Status: StatusPending, Amount: graphql.USD(25000)}
+
+	_, err := service.Approve(ctx, pending, Approval{Bonus: graphql.Money{Cents: 2000, Currency: "EUR"}})
+	assert.EqualError(t, err, "upwork: submissions: bonus must be in USD")
+
+	sub, err := service.Approve(ctx, pending, Approval{Bonus: graphql.USD(2000), Note: "Great work"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, graphql.USD(2000), sub.Bonus)
+	}
+	if reqs := srv.Requests("approveSubmission"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{"submissionId": "s1", "bonus": map[string]interface{}{"rawValue": "20.00", "currency": "USD"}, "note": "Great work"}, reqs[0].Variables["input"])
+	}
+
+	_, err = service.Reject(ctx, *sub, "Too late")
+	assert.EqualError(t, err, "upwork: submissions: submission s1 is APPROVED, only a pending submission can be rejected")
+	_, err = service.Reject(ctx, Submission{Id: "s2", Status: StatusPending}, "")
+	assert.Error(t, err)
+
+	sub, err = service.Reject(ctx, Submission{Id: "s2", Status: StatusPending}, "Missing sources")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Missing sources", sub.RejectReason)
+	}
+	assert.Len(t, srv.Requests("rejectSubmission"), 1)
+}
@@ -37,15 +37,15 @@ func New(c *api.ApiClient) *a {
 
 // Freelancer submits work for the client to approve
 func (r a) RequestApproval(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/submissions.RequestApproval")
 }
 
 // Approve an existing Submission
 func (r a) Approve(submissionId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/submissions.Approve")
 }
 
 // Reject an existing Submission
 func (r a) Reject(submissionId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("hr/submissions.Reject")
 }
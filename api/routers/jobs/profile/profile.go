@@ -37,5 +37,5 @@ func New(c *api.ApiClient) *a {
 
 // Get specific Job's Profile
 func (r a) GetSpecific(key string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("jobs/profile.GetSpecific")
 }
@@ -0,0 +1,215 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/hr/jobs"
+)
+
+// Client history of a job posting
+type ClientHistory struct {
+	PaymentVerified bool
+	MinHires        int     // hires on previous jobs
+	MinFeedback     float64 // average score, 0-5
+}
+
+// Filter of the job search, empty fields are not applied
+type Filter struct {
+	Query       string // search expression
+	CategoryIds []string
+	Skills      []string
+	JobType     jobs.JobType
+	BudgetMin   graphql.Money // fixed-price jobs
+	BudgetMax   graphql.Money
+	HourlyMin   graphql.Money // hourly jobs
+	HourlyMax   graphql.Money
+	Client      ClientHistory
+	PostedSince time.Time
+}
+
+// Validate the filter, reports all invalid fields
+func (f Filter) Validate() error {
+	var errs graphql.ValidationErrors
+	if f.JobType != "" && f.JobType != jobs.JobTypeHourly && f.JobType != jobs.JobTypeFixedPrice {
+		errs.Add("jobType", "invalid type %q", f.JobType)
+	}
+	validateRange(&errs, "budget", f.BudgetMin, f.BudgetMax)
+	validateRange(&errs, "hourly", f.HourlyMin, f.HourlyMax)
+	if f.Client.MinHires < 0 {
+		errs.Add("client.minHires", "must not be negative")
+	}
+	if !(f.Client.MinFeedback >= 0 && f.Client.MinFeedback <= 5) { // rejects NaN too
+		errs.Add("client.minFeedback", "must be from 0 to 5")
+	}
+	return errs.Err()
+}
+
+// Client of a job posting
+type Client struct {
+	Country         string        `json:"country"`
+	PaymentVerified bool          `json:"paymentVerified"`
+	TotalHires      int           `json:"totalHires"`
+	TotalSpent      graphql.Money `json:"totalSpent"`
+	Feedback        float64       `json:"totalFeedback"`
+}
+
+// Job posting found on the marketplace
+type Job struct {
+	Id          string        `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Category    string        `json:"category"`
+	Skills      []string      `json:"skills"`
+	JobType     jobs.JobType  `json:"jobType"`
+	Budget      graphql.Money `json:"amount"`          // fixed-price
+	HourlyMin   graphql.Money `json:"hourlyBudgetMin"` // hourly
+	HourlyMax   graphql.Money `json:"hourlyBudgetMax"`
+	Duration    jobs.Duration `json:"duration"`
+	Client      Client        `json:"client"`
+	Applicants  int           `json:"totalApplicants"`
+	PublishedAt time.Time     `json:"publishedDateTime"`
+}
+
+// Page of jobs
+type Page struct {
+	Jobs       []Job
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Marketplace job search service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const searchQuery = `query marketplaceJobPostingsSearch($filter: MarketplaceJobPostingsSearchFilter, $pagination: Pagination) {
+  marketplaceJobPostingsSearch(marketPlaceJobFilter: $filter, pagination: $pagination) {
+    totalCount
+    edges {
+      node {
+        id title description category skills jobType amount { rawValue currency }
+        hourlyBudgetMin { rawValue currency } hourlyBudgetMax { rawValue currency } duration
+        client { country paymentVerified totalHires totalSpent { rawValue currency } totalFeedback }
+        totalApplicants publishedDateTime
+      }
+    }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// Search the marketplace job postings
+func (s *Service) Search(ctx context.Context, filter Filter, page graphql.Pagination) (*Page, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Search graphql.Connection[Job] `json:"marketplaceJobPostingsSearch"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, searchQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.Search
+	return &Page{Jobs: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get the MarketplaceJobPostingsSearchFilter input
+func (f Filter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if f.Query != "" {
+		vars["searchExpression_eq"] = f.Query
+	}
+	if len(f.CategoryIds) > 0 {
+		vars["categoryIds_any"] = f.CategoryIds
+	}
+	if len(f.Skills) > 0 {
+		vars["skills_any"] = f.Skills
+	}
+	if f.JobType != "" {
+		vars["jobType_eq"] = f.JobType
+	}
+	if r := moneyRange(f.BudgetMin, f.BudgetMax); r != nil {
+		vars["amount_bt"] = r
+	}
+	if r := moneyRange(f.HourlyMin, f.HourlyMax); r != nil {
+		vars["hourlyRate_bt"] = r
+	}
+	if f.Client.PaymentVerified {
+		vars["verifiedPaymentOnly_eq"] = true
+	}
+	if f.Client.MinHires > 0 {
+		vars["clientHires_gte"] = f.Client.MinHires
+	}
+	if f.Client.MinFeedback > 0 {
+		vars["clientFeedback_gte"] = f.Client.MinFeedback
+	}
+	if !f.PostedSince.IsZero() {
+		vars["postedSince_eq"] = f.PostedSince.UTC().Format(time.RFC3339)
+	}
+	return vars
+}
+
+// Get a range input, nil if no bound is set
+func moneyRange(min graphql.Money, max graphql.Money) map[string]interface{} {
+	if min.IsZero() && max.IsZero() {
+		return nil
+	}
+	r := make(map[string]interface{})
+	if !min.IsZero() {
+		r["rangeStart"] = min
+	}
+	if !max.IsZero() {
+		r["rangeEnd"] = max
+	}
+	return r
+}
+
+// Validate the bounds of a range
+func validateRange(errs *graphql.ValidationErrors, field string, min graphql.Money, max graphql.Money) {
+	minValid := validateBound(errs, field+"Min", min)
+	maxValid := validateBound(errs, field+"Max", max)
+	if !minValid || !maxValid || min.IsZero() || max.IsZero() {
+		return
+	}
+	if !min.SameCurrency(max) {
+		errs.Add(field+"Max", "must be in %s", min.CurrencyCode())
+	} else if min.Cents > max.Cents {
+		errs.Add(field+"Max", "must not be less than %s", min)
+	}
+}
+
+// Validate a bound of a range, an unset bound is valid
+func validateBound(errs *graphql.ValidationErrors, field string, m graphql.Money) bool {
+	if m.IsZero() {
+		return true
+	}
+	if err := m.Validate(); err != nil {
+		errs.AddErr(field, err)
+		return false
+	}
+	return true
+}
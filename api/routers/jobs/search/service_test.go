@@ -0,0 +1,85 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/hr/jobs"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Filter{}.Validate())
+	assert.NoError(t, Filter{BudgetMin: graphql.USD(10000)}.Validate())
+
+	err := Filter{
+		JobType:   "PART_TIME",
+		BudgetMin: graphql.USD(50000),
+		BudgetMax: graphql.USD(10000),
+		HourlyMin: graphql.USD(-100),
+		HourlyMax: graphql.Money{Cents: 100, Currency: "EURO"},
+		Client:    ClientHistory{MinFeedback: 6},
+	}.Validate()
+	var errs graphql.ValidationErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		for _, field := range []string{"jobType", "budgetMax", "hourlyMin", "hourlyMax", "client.minFeedback"} {
+			assert.True(t, errs.Has(field), field)
+		}
+		assert.False(t, errs.Has("budgetMin"))
+	}
+
+	err = Filter{Client: ClientHistory{MinFeedback: math.NaN()}}.Validate()
+	assert.EqualError(t, err, "upwork: invalid input: client.minFeedback: must be from 0 to 5")
+
+	err = Filter{HourlyMin: graphql.USD(100), HourlyMax: graphql.Money{Cents: 200, Currency: "EUR"}}.Validate()
+	assert.EqualError(t, err, "upwork: invalid input: hourlyMax: must be in USD")
+}
+
+func TestSearch(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("marketplaceJobPostingsSearch", `{"marketplaceJobPostingsSearch": {"totalCount": 120, "edges": [{"node": {
+		"id": "~j1", "title": "Go developer", "skills": ["Go", "GraphQL"], "jobType": "HOURLY",
+		"hourlyBudgetMin": {"rawValue": "40", "currency": "USD"}, "hourlyBudgetMax": {"rawValue": "70", "currency": "USD"}, "duration": "MONTH",
+		"client": {"country": "US", "paymentVerified": true, "totalHires": 12, "totalSpent": {"rawValue": "15000", "currency": "USD"}, "totalFeedback": 4.9},
+		"totalApplicants": 7, "publishedDateTime": "2023-06-01T10:00:00Z"}}], "pageInfo": {"hasNextPage": true, "endCursor": "c50"}}}`)
+	service := NewService(srv.Client())
+
+	_, err := service.Search(context.Background(), Filter{JobType: "PART_TIME"}, graphql.Pagination{})
+	assert.Error(t, err)
+	assert.Empty(t, srv.Requests("marketplaceJobPostingsSearch"))
+
+	filter := Filter{
+		Query:       "golang",
+		Skills:      []string{"Go"},
+		JobType:     jobs.JobTypeHourly,
+		HourlyMin:   graphql.USD(3000),
+		Client:      ClientHistory{PaymentVerified: true, MinHires: 5},
+		PostedSince: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	page, err := service.Search(context.Background(), filter, graphql.Pagination{})
+	if assert.NoError(t, err) && assert.Len(t, page.Jobs, 1) {
+		job := page.Jobs[0]
+		assert.Equal(t, graphql.USD(7000), job.HourlyMax)
+		assert.Equal(t, jobs.DurationMonth, job.Duration)
+		assert.Equal(t, 12, job.Client.TotalHires)
+		assert.Equal(t, 7, job.Applicants)
+		assert.Equal(t, graphql.Pagination{First: graphql.DefaultPageSize, After: "c50"}, page.PageInfo.Next(graphql.DefaultPageSize))
+	}
+	if reqs := srv.Requests("marketplaceJobPostingsSearch"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"searchExpression_eq":    "golang",
+			"skills_any":             []interface{}{"Go"},
+			"jobType_eq":             "HOURLY",
+			"hourlyRate_bt":          map[string]interface{}{"rangeStart": map[string]interface{}{"rawValue": "30.00", "currency": "USD"}},
+			"verifiedPaymentOnly_eq": true,
+			"clientHires_gte":        float64(5),
+			"postedSince_eq":         "2023-05-01T00:00:00Z",
+		}, reqs[0].Variables["filter"])
+	}
+}
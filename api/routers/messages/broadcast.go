@@ -0,0 +1,197 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2016(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package messages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default number of the messages sent in parallel
+const DefaultBroadcastConcurrency = 4
+
+// Status of a message broadcasted to a room
+type BroadcastStatus string
+
+const (
+	BroadcastOk      BroadcastStatus = "ok"
+	BroadcastFailed  BroadcastStatus = "error"
+	BroadcastSkipped BroadcastStatus = "skipped"
+)
+
+// Reasons of skipping a room
+const (
+	SkippedDryRun      = "dry run"
+	SkippedAlreadySent = "already sent"
+	SkippedDuplicate   = "duplicate"
+)
+
+// Broadcast of a message to many rooms
+type Broadcast struct {
+	Message     string
+	RoomIds     []string
+	Concurrency int           // DefaultBroadcastConcurrency if not set
+	Interval    time.Duration // minimum interval between two messages, not limited if not set
+	DryRun      bool          // validate and report the rooms without sending anything
+	Sent        []string      // rooms the message was already sent to, to resume a broadcast
+}
+
+// Result of a message broadcasted to a room
+type BroadcastResult struct {
+	RoomId string
+	Status BroadcastStatus
+	Story  *Story // the sent message if ok
+	Err    error  // the failure if error
+	Reason string // the reason if skipped
+}
+
+// Results of a broadcast in the order of the rooms
+type BroadcastResults []BroadcastResult
+
+// BroadcastError is returned if the message was not sent to some of the rooms
+type BroadcastError struct {
+	Failed int
+	Total  int
+	Err    error // first failure
+}
+
+func (e *BroadcastError) Error() string {
+	return fmt.Sprintf("upwork: messages: broadcast failed for %d of %d rooms: %v", e.Failed, e.Total, e.Err)
+}
+
+func (e *BroadcastError) Unwrap() error {
+	return e.Err
+}
+
+// Get the rooms the message has been sent to, now or before, to resume the broadcast
+func (r BroadcastResults) Sent() []string {
+	var ids []string
+	for _, res := range r {
+		if res.Status == BroadcastOk || (res.Status == BroadcastSkipped && res.Reason == SkippedAlreadySent) {
+			ids = append(ids, res.RoomId)
+		}
+	}
+	return ids
+}
+
+// Get the rooms the message has not been sent to because of an error
+func (r BroadcastResults) Failed() []string {
+	var ids []string
+	for _, res := range r {
+		if res.Status == BroadcastFailed {
+			ids = append(ids, res.RoomId)
+		}
+	}
+	return ids
+}
+
+// Send a message to many rooms. The results are returned even on failure, the broadcast can be
+// resumed by setting Sent to the results' Sent(), then only the failed rooms are retried.
+func (s *Service) Broadcast(ctx context.Context, b Broadcast) (BroadcastResults, error) {
+	if strings.TrimSpace(b.Message) == "" {
+		return nil, fmt.Errorf("upwork: messages: the message is empty")
+	}
+
+	sent := make(map[string]bool, len(b.Sent))
+	for _, id := range b.Sent {
+		sent[id] = true
+	}
+
+	results := make(BroadcastResults, len(b.RoomIds))
+	var pending []int
+	seen := make(map[string]bool, len(b.RoomIds))
+	for i, id := range b.RoomIds {
+		results[i] = BroadcastResult{RoomId: id, Status: BroadcastSkipped}
+		switch {
+		case id == "":
+			results[i] = BroadcastResult{RoomId: id, Status: BroadcastFailed, Err: fmt.Errorf("upwork: messages: room ID is missing")}
+		case seen[id]:
+			results[i].Reason = SkippedDuplicate
+		case sent[id]:
+			results[i].Reason = SkippedAlreadySent
+		case b.DryRun:
+			results[i].Reason = SkippedDryRun
+		default:
+			pending = append(pending, i)
+		}
+		seen[id] = true
+	}
+
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBroadcastConcurrency
+	}
+
+	// release the rooms to the workers, not faster than the interval
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+
+		var tick <-chan time.Time
+		if b.Interval > 0 {
+			ticker := time.NewTicker(b.Interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for n, i := range pending {
+			if n > 0 && tick != nil {
+				select {
+				case <-tick:
+				case <-ctx.Done():
+				}
+			}
+			jobs <- i
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(pending); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res := &results[i]
+				if err := ctx.Err(); err != nil {
+					res.Status, res.Err = BroadcastFailed, err
+					continue
+				}
+				story, err := s.SendMessage(ctx, res.RoomId, b.Message)
+				if err != nil {
+					res.Status, res.Err = BroadcastFailed, err
+					continue
+				}
+				res.Status, res.Story = BroadcastOk, story
+			}
+		}()
+	}
+	wg.Wait()
+
+	var berr *BroadcastError
+	for _, res := range results {
+		if res.Status == BroadcastFailed {
+			if berr == nil {
+				berr = &BroadcastError{Total: len(results), Err: res.Err}
+			}
+			berr.Failed++
+		}
+	}
+	if berr != nil {
+		return results, berr
+	}
+	return results, nil
+}
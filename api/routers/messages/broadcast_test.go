@@ -0,0 +1,130 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+// stand-in counting the messages sent in parallel, fails for the rooms in failing
+type broadcastServer struct {
+	mu       sync.Mutex
+	inFlight int
+	maxSeen  int
+	failing  map[string]bool
+}
+
+func (b *broadcastServer) handle(req graphqltest.Request) (interface{}, error) {
+	b.mu.Lock()
+	b.inFlight++
+	if b.inFlight > b.maxSeen {
+		b.maxSeen = b.inFlight
+	}
+	b.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inFlight--
+
+	input := req.Variables["input"].(map[string]interface{})
+	if b.failing[input["roomId"].(string)] {
+		return nil, errors.New("temporary failure")
+	}
+	return map[string]interface{}{"sendMessageToRoom": map[string]interface{}{"id": "story-" + input["roomId"].(string), "message": input["message"]}}, nil
+}
+
+func sentRooms(srv *graphqltest.Server) []string {
+	var ids []string
+	for _, req := range srv.Requests("sendMessageToRoom") {
+		ids = append(ids, req.Variables["input"].(map[string]interface{})["roomId"].(string))
+	}
+	return ids
+}
+
+func TestBroadcastResume(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	bs := &broadcastServer{failing: map[string]bool{"r3": true}}
+	srv.Handle("sendMessageToRoom", bs.handle)
+	service := NewService(srv.Client())
+
+	b := Broadcast{Message: "hello", RoomIds: []string{"r1", "r2", "r3", "r2", "r4", "r5"}, Concurrency: 2}
+	results, err := service.Broadcast(context.Background(), b)
+
+	var berr *BroadcastError
+	if assert.True(t, errors.As(err, &berr)) {
+		assert.Equal(t, 1, berr.Failed)
+		assert.Equal(t, 6, berr.Total)
+		var gerrs graphql.Errors
+		assert.True(t, errors.As(err, &gerrs))
+	}
+	assert.Equal(t, 2, bs.maxSeen)
+
+	statuses := make([]BroadcastStatus, len(results))
+	for i, res := range results {
+		statuses[i] = res.Status
+	}
+	assert.Equal(t, []BroadcastStatus{BroadcastOk, BroadcastOk, BroadcastFailed, BroadcastSkipped, BroadcastOk, BroadcastOk}, statuses)
+	assert.Equal(t, SkippedDuplicate, results[3].Reason)
+	assert.Equal(t, "story-r1", results[0].Story.Id)
+	assert.Equal(t, []string{"r3"}, results.Failed())
+	assert.Len(t, srv.Requests("sendMessageToRoom"), 5)
+
+	// resume, only the failed room is retried
+	bs.failing = nil
+	b.Sent = results.Sent()
+	results, err = service.Broadcast(context.Background(), b)
+	assert.NoError(t, err)
+	assert.Empty(t, results.Failed())
+	assert.Equal(t, BroadcastOk, results[2].Status)
+	assert.Equal(t, SkippedAlreadySent, results[0].Reason)
+	assert.ElementsMatch(t, []string{"r1", "r2", "r3", "r4", "r5"}, results.Sent())
+	assert.Len(t, srv.Requests("sendMessageToRoom"), 6)
+	assert.Equal(t, "r3", sentRooms(srv)[5])
+}
+
+func TestBroadcastDryRun(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	service := NewService(srv.Client())
+
+	results, err := service.Broadcast(context.Background(), Broadcast{Message: "hello", RoomIds: []string{"r1", "r2"}, DryRun: true, Sent: []string{"r2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, BroadcastResults{
+		{RoomId: "r1", Status: BroadcastSkipped, Reason: SkippedDryRun},
+		{RoomId: "r2", Status: BroadcastSkipped, Reason: SkippedAlreadySent},
+	}, results)
+	assert.Empty(t, srv.Requests("sendMessageToRoom"))
+
+	_, err = service.Broadcast(context.Background(), Broadcast{RoomIds: []string{"r1"}, DryRun: true})
+	assert.Error(t, err)
+}
+
+func TestBroadcastInterval(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Handle("sendMessageToRoom", (&broadcastServer{}).handle)
+
+	start := time.Now()
+	_, err := NewService(srv.Client()).Broadcast(context.Background(), Broadcast{Message: "hello", RoomIds: []string{"r1", "r2", "r3"}, Concurrency: 3, Interval: 50 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestBroadcastCanceled(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Handle("sendMessageToRoom", (&broadcastServer{}).handle)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	results, err := NewService(srv.Client()).Broadcast(ctx, Broadcast{Message: "hello", RoomIds: []string{"r1", "r2", "r3"}, Concurrency: 1, Interval: 50 * time.Millisecond})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, []string{"r1"}, results.Sent())
+	assert.Equal(t, []string{"r2", "r3"}, results.Failed())
+}
@@ -37,55 +37,55 @@ func New(c *api.ApiClient) *a {
 
 // Retrieve rooms information
 func (r a) GetRooms(company string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.GetRooms")
 }
 
 // Get a specific room information
 func (r a) GetRoomDetails(company string, roomId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.GetRoomDetails")
 }
 
 // Get messages from a specific room
 func (r a) GetRoomMessages(company string, roomId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.GetRoomMessages")
 }
 
 // Get a specific room by offer ID
 func (r a) GetRoomByOffer(company string, offerId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.GetRoomByOffer")
 }
 
 // Get a specific room by application ID
 func (r a) GetRoomByApplication(company string, applicationId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.GetRoomByApplication")
 }
 
 // Get a specific room by contract ID
 func (r a) GetRoomByContract(company string, contractId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.GetRoomByContract")
 }
 
 // Create a new room
 func (r a) CreateRoom(company string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.CreateRoom")
 }
 
 // Send a message to a room
 func (r a) SendMessageToRoom(company string, roomId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.SendMessageToRoom")
 }
 
 // Send a message to a batch of rooms
 func (r a) SendMessageToRooms(company string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.SendMessageToRooms")
 }
 
 // Update a room settings
 func (r a) UpdateRoomSettings(company string, roomId string, username string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.UpdateRoomSettings")
 }
 
 // Update the metadata of a room
 func (r a) UpdateRoomMetadata(company string, roomId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("messages.UpdateRoomMetadata")
 }
@@ -0,0 +1,330 @@
+// Router for Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2016(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package messages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upwork/golang-upwork-oauth2/api"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+)
+
+// ErrRoomBinding is returned if a new room is not bound to exactly one offer, application or contract
+var ErrRoomBinding = errors.New("upwork: messages: the room must be bound to exactly one offer, application or contract")
+
+// Type of a room
+type RoomType string
+
+const (
+	RoomTypeOneOnOne    RoomType = "ONE_ON_ONE"
+	RoomTypeGroup       RoomType = "GROUP"
+	RoomTypeInteractive RoomType = "INTERACTIVE"
+)
+
+// Role of a participant in a room
+type ParticipantRole string
+
+const (
+	ParticipantOwner  ParticipantRole = "OWNER"
+	ParticipantAdmin  ParticipantRole = "ADMIN"
+	ParticipantMember ParticipantRole = "MEMBER"
+)
+
+// Reference to an object by its ID
+type Ref struct {
+	Id string `json:"id"`
+}
+
+// User taking part in a conversation
+type User struct {
+	Id   string `json:"id"`
+	Nid  string `json:"nid"`
+	Name string `json:"name"`
+}
+
+// Organization of a participant
+type Organization struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Participant of a room
+type Participant struct {
+	User         User            `json:"user"`
+	Organization *Organization   `json:"organization"`
+	Role         ParticipantRole `json:"role"`
+}
+
+// Story, i.e. a message, posted to a room
+type Story struct {
+	Id        string    `json:"id"`
+	Message   string    `json:"message"`
+	User      *User     `json:"user"` // nil for system stories
+	CreatedAt time.Time `json:"createdDateTime"`
+	UpdatedAt time.Time `json:"updatedDateTime"`
+}
+
+// Room
+type Room struct {
+	Id           string        `json:"id"`
+	RoomName     string        `json:"roomName"`
+	RoomType     RoomType      `json:"roomType"`
+	Topic        string        `json:"topic"`
+	Favorite     bool          `json:"favorite"`
+	NumUnread    int           `json:"numUnread"`
+	CreatedAt    time.Time     `json:"createdAtDateTime"`
+	Participants []Participant `json:"roomUsers"`
+	LatestStory  *Story        `json:"latestStory"`
+	Offer        *Ref          `json:"offer"`
+	Application  *Ref          `json:"vendorProposal"`
+	Contract     *Ref          `json:"contract"`
+}
+
+// Filter of the room list, empty fields are not applied
+type RoomFilter struct {
+	RoomType      RoomType
+	UnreadOnly    bool
+	FavoriteOnly  bool
+	ActiveSince   time.Time
+	OfferId       string
+	ApplicationId string
+	ContractId    string
+}
+
+// Page of rooms
+type RoomPage struct {
+	Rooms      []Room
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Page of stories
+type StoryPage struct {
+	Stories    []Story
+	TotalCount int
+	PageInfo   graphql.PageInfo
+}
+
+// Input of a new room, bound to exactly one offer, application or contract
+type NewRoom struct {
+	RoomName      string
+	RoomType      RoomType // RoomTypeOneOnOne by default
+	Topic         string
+	UserIds       []string // participants besides the authorized user
+	OfferId       string
+	ApplicationId string
+	ContractId    string
+}
+
+// Messaging service based on GraphQL API
+type Service struct {
+	client *api.ApiClient
+}
+
+const storyFields = `id message createdDateTime updatedDateTime user { id nid name }`
+
+const roomFields = `id roomName roomType topic favorite numUnread createdAtDateTime
+	roomUsers { user { id nid name } organization { id name } role }
+	latestStory { ` + storyFields + ` }
+	offer { id } vendorProposal { id } contract { id }`
+
+const roomListQuery = `query roomList($filter: RoomFilter, $pagination: Pagination) {
+  roomList(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + roomFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const roomQuery = `query room($id: ID!) {
+  room(id: $id) { ` + roomFields + ` }
+}`
+
+const roomStoriesQuery = `query roomStories($filter: RoomStoryFilter, $pagination: Pagination) {
+  roomStories(filter: $filter, pagination: $pagination) {
+    totalCount
+    edges { node { ` + storyFields + ` } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const createRoomMutation = `mutation createRoomV2($input: RoomCreateInputV2!) {
+  createRoomV2(input: $input) { ` + roomFields + ` }
+}`
+
+const sendMessageMutation = `mutation sendMessageToRoom($input: RoomStoryCreateInputV2!) {
+  sendMessageToRoom(input: $input) { ` + storyFields + ` }
+}`
+
+// Constructor
+func NewService(c *api.ApiClient) *Service {
+	return &Service{c}
+}
+
+// List the rooms of the authorized user
+func (s *Service) ListRooms(ctx context.Context, filter RoomFilter, page graphql.Pagination) (*RoomPage, error) {
+	var data struct {
+		RoomList graphql.Connection[Room] `json:"roomList"`
+	}
+	vars := map[string]interface{}{"filter": filter.variables(), "pagination": page.OrDefault()}
+	if err := graphql.Do(ctx, s.client, roomListQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.RoomList
+	return &RoomPage{Rooms: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Get a specific room
+func (s *Service) GetRoom(ctx context.Context, roomId string) (*Room, error) {
+	var data struct {
+		Room *Room `json:"room"`
+	}
+	if err := graphql.Do(ctx, s.client, roomQuery, map[string]interface{}{"id": roomId}, &data); err != nil {
+		return nil, err
+	}
+	if data.Room == nil {
+		return nil, graphql.ErrNotFound
+	}
+	return data.Room, nil
+}
+
+// List the stories of a specific room
+func (s *Service) ListStories(ctx context.Context, roomId string, page graphql.Pagination) (*StoryPage, error) {
+	var data struct {
+		RoomStories graphql.Connection[Story] `json:"roomStories"`
+	}
+	vars := map[string]interface{}{
+		"filter":     map[string]interface{}{"roomId_eq": roomId},
+		"pagination": page.OrDefault(),
+	}
+	if err := graphql.Do(ctx, s.client, roomStoriesQuery, vars, &data); err != nil {
+		return nil, err
+	}
+
+	c := data.RoomStories
+	return &StoryPage{Stories: c.Nodes(), TotalCount: c.TotalCount, PageInfo: c.PageInfo}, nil
+}
+
+// Create a new room
+func (s *Service) CreateRoom(ctx context.Context, room NewRoom) (*Room, error) {
+	input, err := room.variables()
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		CreateRoomV2 *Room `json:"createRoomV2"`
+	}
+	if err := graphql.Do(ctx, s.client, createRoomMutation, map[string]interface{}{"input": input}, &data); err != nil {
+		return nil, err
+	}
+	if data.CreateRoomV2 == nil {
+		return nil, fmt.Errorf("upwork: messages: the room was not created")
+	}
+	return data.CreateRoomV2, nil
+}
+
+// Send a message to a room
+func (s *Service) SendMessage(ctx context.Context, roomId string, message string) (*Story, error) {
+	if roomId == "" {
+		return nil, fmt.Errorf("upwork: messages: room ID is missing")
+	}
+	if strings.TrimSpace(message) == "" {
+		return nil, fmt.Errorf("upwork: messages: the message is empty")
+	}
+
+	var data struct {
+		SendMessageToRoom *Story `json:"sendMessageToRoom"`
+	}
+	vars := map[string]interface{}{"input": map[string]interface{}{"roomId": roomId, "message": message}}
+	if err := graphql.Do(ctx, s.client, sendMessageMutation, vars, &data); err != nil {
+		return nil, err
+	}
+	if data.SendMessageToRoom == nil {
+		return nil, fmt.Errorf("upwork: messages: the message was not sent")
+	}
+	return data.SendMessageToRoom, nil
+}
+
+// Get the RoomFilter input
+func (f RoomFilter) variables() map[string]interface{} {
+	vars := make(map[string]interface{})
+	if f.RoomType != "" {
+		vars["roomType_eq"] = f.RoomType
+	}
+	if f.UnreadOnly {
+		vars["unreadRoomsOnly_eq"] = true
+	}
+	if f.FavoriteOnly {
+		vars["favorite_eq"] = true
+	}
+	if !f.ActiveSince.IsZero() {
+		vars["activeSince_eq"] = f.ActiveSince.UTC().Format(time.RFC3339)
+	}
+	if f.OfferId != "" {
+		vars["offerId_eq"] = f.OfferId
+	}
+	if f.ApplicationId != "" {
+		vars["vendorProposalId_eq"] = f.ApplicationId
+	}
+	if f.ContractId != "" {
+		vars["contractId_eq"] = f.ContractId
+	}
+	return vars
+}
+
+// Validate the room and get the RoomCreateInputV2 input
+func (r NewRoom) variables() (map[string]interface{}, error) {
+	bindings := 0
+	for _, id := range []string{r.OfferId, r.ApplicationId, r.ContractId} {
+		if id != "" {
+			bindings++
+		}
+	}
+	if bindings != 1 {
+		return nil, ErrRoomBinding
+	}
+	if strings.TrimSpace(r.RoomName) == "" {
+		return nil, fmt.Errorf("upwork: messages: room name is missing")
+	}
+
+	roomType := r.RoomType
+	if roomType == "" {
+		roomType = RoomTypeOneOnOne
+	}
+	users := make([]map[string]interface{}, len(r.UserIds))
+	for i, id := range r.UserIds {
+		users[i] = map[string]interface{}{"userId": id}
+	}
+
+	input := map[string]interface{}{"roomName": r.RoomName, "roomType": roomType, "users": users}
+	if r.Topic != "" {
+		input["topic"] = r.Topic
+	}
+	switch {
+	case r.OfferId != "":
+		input["offerId"] = r.OfferId
+	case r.ApplicationId != "":
+		input["vendorProposalId"] = r.ApplicationId
+	case r.ContractId != "":
+		input["contractId"] = r.ContractId
+	}
+	return input, nil
+}
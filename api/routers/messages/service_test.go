@@ -0,0 +1,183 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql"
+	"github.com/upwork/golang-upwork-oauth2/api/routers/graphql/graphqltest"
+)
+
+const roomJson = `{
+	"id": "room_1",
+	"roomName": "Logo design",
+	"roomType": "ONE_ON_ONE",
+	"topic": "logo",
+	"favorite": true,
+	"numUnread": 2,
+	"createdAtDateTime": "2023-05-01T10:00:00Z",
+	"roomUsers": [
+		{"user": {"id": "u1", "nid": "john", "name": "John"}, "organization": {"id": "o1", "name": "Acme"}, "role": "OWNER"},
+		{"user": {"id": "u2", "nid": "jane", "name": "Jane"}, "organization": null, "role": "MEMBER"}
+	],
+	"latestStory": {"id": "s2", "message": "hi", "createdDateTime": "2023-05-02T10:00:00Z", "updatedDateTime": "2023-05-02T10:00:00Z", "user": {"id": "u2", "nid": "jane", "name": "Jane"}},
+	"offer": null,
+	"vendorProposal": null,
+	"contract": {"id": "c1"}
+}`
+
+func TestListRooms(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("roomList", `{"roomList": {"totalCount": 3, "edges": [{"node": `+roomJson+`}], "pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"}}}`)
+
+	since := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	page, err := NewService(srv.Client()).ListRooms(context.Background(), RoomFilter{UnreadOnly: true, ContractId: "c1", ActiveSince: since}, graphql.Pagination{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 3, page.TotalCount)
+	assert.Equal(t, graphql.PageInfo{HasNextPage: true, EndCursor: "cursor-1"}, page.PageInfo)
+	if assert.Len(t, page.Rooms, 1) {
+		room := page.Rooms[0]
+		assert.Equal(t, "room_1", room.Id)
+		assert.Equal(t, RoomTypeOneOnOne, room.RoomType)
+		assert.Equal(t, 2, room.NumUnread)
+		assert.Equal(t, &Ref{Id: "c1"}, room.Contract)
+		assert.Nil(t, room.Offer)
+		if assert.Len(t, room.Participants, 2) {
+			assert.Equal(t, ParticipantOwner, room.Participants[0].Role)
+			assert.Equal(t, "Acme", room.Participants[0].Organization.Name)
+			assert.Nil(t, room.Participants[1].Organization)
+		}
+		assert.Equal(t, "hi", room.LatestStory.Message)
+		assert.True(t, time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC).Equal(room.LatestStory.CreatedAt))
+	}
+
+	var vars struct {
+		Filter     map[string]interface{}
+		Pagination graphql.Pagination
+	}
+	if reqs := srv.Requests("roomList"); assert.Len(t, reqs, 1) {
+		assert.NoError(t, reqs[0].Decode(&vars))
+	}
+	assert.Equal(t, map[string]interface{}{"unreadRoomsOnly_eq": true, "contractId_eq": "c1", "activeSince_eq": "2023-05-01T00:00:00Z"}, vars.Filter)
+	assert.Equal(t, graphql.Pagination{First: graphql.DefaultPageSize}, vars.Pagination)
+}
+
+func TestGetRoom(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Handle("room", func(req graphqltest.Request) (interface{}, error) {
+		if req.Variables["id"] == "room_1" {
+			return map[string]interface{}{"room": graphqltest.Raw(roomJson)}, nil
+		}
+		return map[string]interface{}{"room": nil}, nil
+	})
+	service := NewService(srv.Client())
+
+	room, err := service.GetRoom(context.Background(), "room_1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Logo design", room.RoomName)
+	}
+
+	_, err = service.GetRoom(context.Background(), "room_2")
+	assert.Equal(t, graphql.ErrNotFound, err)
+}
+
+func TestListStoriesPaginated(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Handle("roomStories", func(req graphqltest.Request) (interface{}, error) {
+		var vars struct {
+			Filter struct {
+				RoomId string `json:"roomId_eq"`
+			}
+			Pagination graphql.Pagination
+		}
+		req.Decode(&vars)
+		if vars.Filter.RoomId != "room_1" || vars.Pagination.First != 1 {
+			return nil, errors.New("unexpected variables")
+		}
+		if vars.Pagination.After == "" {
+			return graphqltest.Raw(`{"roomStories": {"totalCount": 2, "edges": [{"node": {"id": "s1", "message": "hello"}}], "pageInfo": {"hasNextPage": true, "endCursor": "s1"}}}`), nil
+		}
+		return graphqltest.Raw(`{"roomStories": {"totalCount": 2, "edges": [{"node": {"id": "s2", "message": "hi", "user": null}}], "pageInfo": {"hasNextPage": false, "endCursor": "s2"}}}`), nil
+	})
+	service := NewService(srv.Client())
+
+	var messages []string
+	page := graphql.Pagination{First: 1}
+	for {
+		stories, err := service.ListStories(context.Background(), "room_1", page)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, s := range stories.Stories {
+			messages = append(messages, s.Message)
+		}
+		if !stories.PageInfo.HasNextPage {
+			break
+		}
+		page = stories.PageInfo.Next(1)
+	}
+	assert.Equal(t, []string{"hello", "hi"}, messages)
+}
+
+func TestCreateRoom(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Respond("createRoomV2", `{"createRoomV2": `+roomJson+`}`)
+	service := NewService(srv.Client())
+
+	_, err := service.CreateRoom(context.Background(), NewRoom{RoomName: "Logo design"})
+	assert.Equal(t, ErrRoomBinding, err)
+	_, err = service.CreateRoom(context.Background(), NewRoom{RoomName: "Logo design", OfferId: "1", ContractId: "2"})
+	assert.Equal(t, ErrRoomBinding, err)
+	_, err = service.CreateRoom(context.Background(), NewRoom{ApplicationId: "1"})
+	assert.Error(t, err)
+	assert.Empty(t, srv.Requests("createRoomV2"))
+
+	room, err := service.CreateRoom(context.Background(), NewRoom{RoomName: "Logo design", UserIds: []string{"u2"}, ApplicationId: "p1"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "room_1", room.Id)
+	}
+	if reqs := srv.Requests("createRoomV2"); assert.Len(t, reqs, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"roomName":         "Logo design",
+			"roomType":         "ONE_ON_ONE",
+			"users":            []interface{}{map[string]interface{}{"userId": "u2"}},
+			"vendorProposalId": "p1",
+		}, reqs[0].Variables["input"])
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	srv := graphqltest.NewServer(t)
+	srv.Handle("sendMessageToRoom", func(req graphqltest.Request) (interface{}, error) {
+		input := req.Variables["input"].(map[string]interface{})
+		if input["roomId"] != "room_1" {
+			return nil, &graphql.Error{Message: "room not found", Path: []interface{}{"sendMessageToRoom"}, Extensions: map[string]interface{}{"code": "NOT_FOUND"}}
+		}
+		return graphqltest.Raw(`{"sendMessageToRoom": {"id": "s3", "message": "` + input["message"].(string) + `", "user": {"id": "u1"}}}`), nil
+	})
+	service := NewService(srv.Client())
+
+	story, err := service.SendMessage(context.Background(), "room_1", "hello")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "s3", story.Id)
+		assert.Equal(t, "hello", story.Message)
+	}
+
+	_, err = service.SendMessage(context.Background(), "room_2", "hello")
+	var gerrs graphql.Errors
+	if assert.True(t, errors.As(err, &gerrs)) {
+		assert.Equal(t, "NOT_FOUND", gerrs[0].Code())
+	}
+	assert.EqualError(t, err, "upwork: graphql: sendMessageToRoom: room not found")
+
+	_, err = service.SendMessage(context.Background(), "room_1", " ")
+	assert.Error(t, err)
+	assert.Len(t, srv.Requests("sendMessageToRoom"), 2)
+}
@@ -37,35 +37,35 @@ func New(c *api.ApiClient) *a {
 
 // Get categories (V2)
 func (r a) GetCategoriesV2() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetCategoriesV2")
 }
 
 // Get skills
 func (r a) GetSkills() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetSkills")
 }
 
 // Get skills (V2)
 func (r a) GetSkillsV2() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetSkillsV2")
 }
 
 // Get specialties
 func (r a) GetSpecialties() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetSpecialties")
 }
 
 // Get regions
 func (r a) GetRegions() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetRegions")
 }
 
 // Get tests
 func (r a) GetTests() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetTests")
 }
 
 // Get reasons
 func (r a) GetReasons(params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("metadata.GetReasons")
 }
@@ -37,20 +37,20 @@ func New(c *api.ApiClient) *a {
 
 // Get Companies List
 func (r a) GetList() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/companies.GetList")
 }
 
 // Get Specific Company
 func (r a) GetSpecific(cmpReference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/companies.GetSpecific")
 }
 
 // Get Teams in Company
 func (r a) GetTeams(cmpReference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/companies.GetTeams")
 }
 
 // Get Users in Company
 func (r a) GetUsers(cmpReference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/companies.GetUsers")
 }
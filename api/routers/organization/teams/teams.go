@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get Teams info
 func (r a) GetList(params ...map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/teams.GetList")
 }
 
 // Get Users in Team
 func (r a) GetUsersInTeam(teamReference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/teams.GetUsersInTeam")
 }
@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get Auth User Info
 func (r a) GetMyInfo() (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/users.GetMyInfo")
 }
 
 // Get Specific User Info
 func (r a) GetSpecific(userReference string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("organization/users.GetSpecific")
 }
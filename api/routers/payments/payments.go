@@ -37,5 +37,5 @@ func New(c *api.ApiClient) *a {
 
 // Submit a Custom Payment
 func (r a) SubmitBonus(teamReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("payments.SubmitBonus")
 }
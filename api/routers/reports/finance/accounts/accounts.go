@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Generate Financial Reports for an owned Account
 func (r a) GetOwned(freelancerReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/accounts.GetOwned")
 }
 
 // Generate Financial Reports for a Specific Account
 func (r a) GetSpecific(entityReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/accounts.GetSpecific")
 }
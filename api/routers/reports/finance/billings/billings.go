@@ -37,25 +37,25 @@ func New(c *api.ApiClient) *a {
 
 // Generate Billing Reports for a Specific Freelancer
 func (r a) GetByFreelancer(freelancerReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/billings.GetByFreelancer")
 }
 
 // Generate Billing Reports for a Specific Freelancer's Team
 func (r a) GetByFreelancersTeam(freelancerTeamReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/billings.GetByFreelancersTeam")
 }
 
 // Generate Billing Reports for a Specific Freelancer's Company
 func (r a) GetByFreelancersCompany(freelancerCompanyReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/billings.GetByFreelancersCompany")
 }
 
 // Generate Billing Reports for a Specific Buyer's Team
 func (r a) GetByBuyersTeam(buyerTeamReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/billings.GetByBuyersTeam")
 }
 
 // Generate Billing Reports for a Specific Buyer's Company
 func (r a) GetByBuyersCompany(buyerCompanyReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/billings.GetByBuyersCompany")
 }
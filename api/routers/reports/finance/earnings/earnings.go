@@ -37,25 +37,25 @@ func New(c *api.ApiClient) *a {
 
 // Generate Earning Reports for a Specific Freelancer
 func (r a) GetByFreelancer(freelancerReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/earnings.GetByFreelancer")
 }
 
 // Generate Earning Reports for a Specific Freelancer's Team
 func (r a) GetByFreelancersTeam(freelancerTeamReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/earnings.GetByFreelancersTeam")
 }
 
 // Generate Earning Reports for a Specific Freelancer's Company
 func (r a) GetByFreelancersCompany(freelancerCompanyReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/earnings.GetByFreelancersCompany")
 }
 
 // Generate Earning Reports for a Specific Buyer's Team
 func (r a) GetByBuyersTeam(buyerTeamReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/earnings.GetByBuyersTeam")
 }
 
 // Generate Earning Reports for a Specific Buyer's Company
 func (r a) GetByBuyersCompany(buyerCompanyReference string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/finance/earnings.GetByBuyersCompany")
 }
@@ -37,45 +37,30 @@ func New(c *api.ApiClient) *a {
 
 // Generate Time Reports for a Specific Team (with financial info)
 func (r a) GetByTeamFull(company string, team string, params map[string]string) (*http.Response, interface{}) {
-	return r.getByType(company, team, "", params, false)
+	return nil, api.Deprecated("reports/time.GetByTeamFull")
 }
 
 // Generate Time Reports for a Specific Team (hide financial info)
 func (r a) GetByTeamFullLimited(company string, team string, params map[string]string) (*http.Response, interface{}) {
-	return r.getByType(company, team, "", params, true)
+	return nil, api.Deprecated("reports/time.GetByTeamFullLimited")
 }
 
 // Generating Agency Specific Reports
 func (r a) GetByAgency(company string, agency string, params map[string]string) (*http.Response, interface{}) {
-	return r.getByType(company, "", agency, params, false)
+	return nil, api.Deprecated("reports/time.GetByAgency")
 }
 
 // Generating Company Wide Reports
 func (r a) GetByCompany(company string, params map[string]string) (*http.Response, interface{}) {
-	return r.getByType(company, "", "", params, false)
+	return nil, api.Deprecated("reports/time.GetByCompany")
 }
 
 // Generating Freelancer's Specific Reports (with financial info)
 func (r a) GetByFreelancerFull(freelancerId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/time.GetByFreelancerFull")
 }
 
 // Generating Freelancer's Specific Reports (hide financial info)
 func (r a) GetByFreelancerLimited(freelancerId string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
-}
-
-// Get by type
-func (r a) getByType(company string, team string, agency string, params map[string]string, hideFinDetails bool) (*http.Response, interface{}) {
-	url := ""
-	if team != "" {
-		url = "/teams/" + team
-		if hideFinDetails {
-			url = url + "/hours"
-		}
-	} else if agency != "" {
-		url = "/agencies/" + agency
-	}
-
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("reports/time.GetByFreelancerLimited")
 }
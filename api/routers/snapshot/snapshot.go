@@ -37,15 +37,15 @@ func New(c *api.ApiClient) *a {
 
 // Get snapshot info by specific contract
 func (r a) GetByContract(contractId string, ts string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("snapshot.GetByContract")
 }
 
 // Update snapshot by specific contract
 func (r a) UpdateByContract(contractId string, ts string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("snapshot.UpdateByContract")
 }
 
 // Delete snapshot by specific contract
 func (r a) DeleteByContract(contractId string, ts string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("snapshot.DeleteByContract")
 }
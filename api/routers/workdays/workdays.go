@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get Workdays by Company
 func (r a) GetByCompany(company string, fromDate string, tillDate string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("workdays.GetByCompany")
 }
 
 // Get Workdays by Contract
 func (r a) GetByContract(contract string, fromDate string, tillDate string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("workdays.GetByContract")
 }
@@ -37,10 +37,10 @@ func New(c *api.ApiClient) *a {
 
 // Get Workdiary by Company
 func (r a) GetByCompany(company string, date string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("workdiary.GetByCompany")
 }
 
 // Get Workdiary by Contract
 func (r a) GetByContract(contract string, date string, params map[string]string) (*http.Response, interface{}) {
-	panic("The legacy API was deprecated. Please, use GraphQL call - see example in this library.")
+	return nil, api.Deprecated("workdiary.GetByContract")
 }
@@ -0,0 +1,200 @@
+// Package implements access to Upwork API
+//
+// Licensed under the Upwork's API Terms of Use;
+// you may not use this file except in compliance with the Terms.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author::    Maksym Novozhylov (mnovozhilov@upwork.com)
+// Copyright:: Copyright 2018(c) Upwork.com
+// License::   See LICENSE.txt and TOS - https://developers.upwork.com/api-tos.html
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrTokenNotFound is returned by a TokenStore that has no token saved yet
+var ErrTokenNotFound = errors.New("upwork: token not found in the store")
+
+// TokenStore keeps an access/refresh token pair between runs
+type TokenStore interface {
+	LoadToken() (*oauth2.Token, error)
+	SaveToken(t *oauth2.Token) error
+	DeleteToken() error
+}
+
+// TokenLocker is implemented by a TokenStore that can be shared between processes.
+// The lock is held while the token gets refreshed, so only one process refreshes
+// and the others re-read the token it saved.
+type TokenLocker interface {
+	LockToken() (unlock func() error, err error)
+}
+
+// TokenFile is a TokenStore that keeps the token in a json file, which may be
+// shared by several processes, e.g. cron jobs using the same credentials
+type TokenFile struct {
+	fn string
+}
+
+// Create a token store for a specific json file. Refreshes are coordinated between
+// processes using a lock file on unix platforms, elsewhere only within this process.
+func NewTokenFile(fn string) *TokenFile {
+	return &TokenFile{fn: fn}
+}
+
+// Read the token from the file
+func (f *TokenFile) LoadToken() (*oauth2.Token, error) {
+	b, err := os.ReadFile(f.fn)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTokenNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	var data tokenFileData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, errors.New("token file " + f.fn + ": " + err.Error())
+	}
+	if data.Token == nil || (data.AccessToken == "" && data.RefreshToken == "") {
+		return nil, ErrTokenNotFound
+	}
+	if data.Scope != "" {
+		return data.Token.WithExtra(map[string]interface{}{"scope": data.Scope}), nil
+	}
+
+	return data.Token, nil
+}
+
+// Save the token, readers never see a partially written file
+func (f *TokenFile) SaveToken(t *oauth2.Token) error {
+	data := tokenFileData{Token: t, Scope: strings.Join(tokenScopes(t), " ")}
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(f.fn, b, 0600)
+}
+
+// tokenFileData is the content of a token file, the granted scopes are kept next to the token
+type tokenFileData struct {
+	*oauth2.Token
+	Scope string `json:"scope,omitempty"`
+}
+
+// Remove the token file, e.g. once the token was revoked
+func (f *TokenFile) DeleteToken() error {
+	if err := os.Remove(f.fn); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Acquire an exclusive lock on the token file, blocks until the lock is available.
+// On non-unix platforms the lock has no cross-process guarantee, see NewTokenFile.
+func (f *TokenFile) LockToken() (func() error, error) {
+	return lockFile(f.fn + ".lock")
+}
+
+// storeTokenSource refreshes a token saved in a TokenStore. If the store is a TokenLocker,
+// the token is re-read under the lock first, so a token refreshed by another process is
+// reused instead of being refreshed once again.
+type storeTokenSource struct {
+	ctx   context.Context
+	conf  *oauth2.Config
+	store TokenStore
+	t     *oauth2.Token
+}
+
+// Token returns a valid token, refreshing it if needed
+func (s *storeTokenSource) Token() (*oauth2.Token, error) {
+	if l, ok := s.store.(TokenLocker); ok {
+		unlock, err := l.LockToken()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	t, err := s.store.LoadToken()
+	if err != nil && err != ErrTokenNotFound {
+		return nil, err
+	}
+	if t != nil && t.Valid() {
+		s.t = t
+		return t, nil
+	}
+
+	// the stored refresh token can be newer if it was rotated by another process
+	refreshToken := s.t.RefreshToken
+	if t != nil && t.RefreshToken != "" {
+		refreshToken = t.RefreshToken
+	}
+
+	t, err = s.conf.TokenSource(s.ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveToken(t); err != nil {
+		return nil, err
+	}
+	s.t = t
+
+	return t, nil
+}
+
+// Get the scopes granted for a token, if reported by the token endpoint
+func tokenScopes(t *oauth2.Token) []string {
+	switch scope := t.Extra("scope").(type) {
+	case string:
+		return strings.Fields(scope)
+	case []interface{}:
+		scopes := make([]string, 0, len(scope))
+		for _, s := range scope {
+			if s, ok := s.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	}
+	return nil
+}
+
+// Write a file via a temporary file in the same directory and rename, so the
+// content is replaced atomically
+func writeFileAtomic(fn string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(fn), "."+filepath.Base(fn)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fn)
+}
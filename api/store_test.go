@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+// local token server, rotates the refresh token on every refresh like Upwork does
+type tokenServer struct {
+	mu           sync.Mutex
+	refreshes    int
+	refreshToken string
+}
+
+func (s *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/ping" {
+		fmt.Fprint(w, r.Header.Get("Authorization"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.FormValue("refresh_token") != s.refreshToken {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": "invalid_grant"}`)
+		return
+	}
+
+	time.Sleep(100 * time.Millisecond) // widen the window for a race
+	s.refreshes++
+	s.refreshToken = fmt.Sprintf("refresh-%d", s.refreshes)
+	fmt.Fprintf(w, `{"access_token": "access-%d", "refresh_token": "%s", "token_type": "bearer", "expires_in": 3600}`, s.refreshes, s.refreshToken)
+}
+
+func setupTokenFileClient(tokenUrl string, fn string) ApiClient {
+	client := Setup(&Config{ClientId: "clientid", ClientSecret: "clientsecret", RedirectUri: "https://a.callback.url"})
+	client.oconf.Endpoint.TokenURL = tokenUrl
+	client.SetTokenStore(NewTokenFile(fn))
+
+	return client
+}
+
+func TestTokenFile(t *testing.T) {
+	store := NewTokenFile(filepath.Join(t.TempDir(), "token.json"))
+
+	_, err := store.LoadToken()
+	assert.Equal(t, ErrTokenNotFound, err)
+
+	expiry := time.Now().Add(time.Hour).Round(time.Second)
+	token := (&oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: expiry}).WithExtra(map[string]interface{}{"scope": "openid profile:read"})
+	if assert.NoError(t, store.SaveToken(token)) {
+		token, err := store.LoadToken()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "access", token.AccessToken)
+			assert.Equal(t, "refresh", token.RefreshToken)
+			assert.True(t, expiry.Equal(token.Expiry))
+			assert.Equal(t, []string{"openid", "profile:read"}, tokenScopes(token))
+		}
+	}
+}
+
+// TestTokenFileRefreshProcess is run as a subprocess by TestTokenFileSharedRefresh
+func TestTokenFileRefreshProcess(t *testing.T) {
+	if os.Getenv("UPWORK_TEST_TOKEN_FILE") == "" {
+		t.Skip("helper process")
+	}
+
+	ctx := context.Background()
+	client := setupTokenFileClient(os.Getenv("UPWORK_TEST_TOKEN_URL"), os.Getenv("UPWORK_TEST_TOKEN_FILE"))
+	if !client.HasAccessToken(ctx) {
+		t.Fatal("token file is empty")
+	}
+
+	resp, err := client.oclient.Get(os.Getenv("UPWORK_TEST_TOKEN_URL") + "/ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, b := formatResponse(resp, err)
+	fmt.Printf("authorization: %s\n", b)
+}
+
+func TestTokenFileSharedRefresh(t *testing.T) {
+	if !crossProcessLocking {
+		t.Skip("file locking is not supported on this platform")
+	}
+
+	srv := &tokenServer{refreshToken: "refresh-0"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	fn := filepath.Join(t.TempDir(), "token.json")
+	expired := &oauth2.Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}
+	if err := NewTokenFile(fn).SaveToken(expired); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	outputs := make([]string, 4)
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cmd := exec.Command(os.Args[0], "-test.run=^TestTokenFileRefreshProcess$")
+			cmd.Env = append(os.Environ(), "UPWORK_TEST_TOKEN_URL="+ts.URL, "UPWORK_TEST_TOKEN_FILE="+fn)
+			out, err := cmd.CombinedOutput()
+			assert.NoError(t, err, string(out))
+			outputs[i] = string(out)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, srv.refreshes)
+	for _, out := range outputs {
+		assert.True(t, strings.Contains(out, "authorization: Bearer access-1"), out)
+	}
+
+	token, err := NewTokenFile(fn).LoadToken()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "access-1", token.AccessToken)
+		assert.Equal(t, "refresh-1", token.RefreshToken)
+	}
+}
+
+func TestLockInProcess(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "token.json.lock")
+	unlock, err := lockInProcess(fn)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := lockInProcess(fn)
+		if assert.NoError(t, err) {
+			close(acquired)
+			unlock()
+		}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the lock is held twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.NoError(t, unlock())
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("the lock is not released")
+	}
+}
@@ -38,11 +38,18 @@ func main() {
 	       "client_id": "clientid",
 	       "client_secret": "clientsecret",
 	   }
-	   config := api.NewConfig(settings)
+	   config, err := api.ParseConfig(settings)
+	   if err != nil {
+	       panic(err)
+	   }
 
 	   //or read them from a specific configuration file
 	   config := api.ReadConfig(cfgFile)
 	   config.Print()
+
+	   // refreshed tokens can be saved back to the configuration file automatically
+	   // (or set "auto_persist": true in the file), or explicitly using config.Persist(cfgFile)
+	   config.AutoPersist = true
 	*/
 
 	/* it is possible to setup a custom http client if needed
@@ -71,6 +78,18 @@ func main() {
 	       return err
 	   }
 	   client.SetRefreshTokenNotifyFunc(f)
+
+	   // it is possible to keep the token pair in a file shared by several processes,
+	   // only one of them refreshes the token, the others re-read the refreshed one
+	   client.SetTokenStore(api.NewTokenFile("token.json"))
+
+	   // once the refresh token is revoked the requests fail with api.ErrReauthorizationRequired,
+	   // a long-running service can notify a human with a fresh authorization URL
+	   client.OnReauthRequired(func(account string) string {
+	       aurl := client.GetAuthorizationUrl("random-state")
+	       fmt.Println("Re-authorization is required for", account, "visit", aurl)
+	       return aurl
+	   })
 	*/
 	// we need an access/refresh token pair in case we haven't received it yet
 	if !client.HasAccessToken(ctx) {